@@ -0,0 +1,4 @@
+ephemeral "ctfd_token" "exporter" {
+  description = "Scoreboard exporter token."
+  expiration  = "2222-01-01"
+}
@@ -0,0 +1,30 @@
+resource "ctfd_user" "ctfer" {
+  name     = "CTFer"
+  email    = "ctfer-io@protonmail.com"
+  password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+  name     = "CTFer"
+  email    = "ctfer-io@protonmail.com"
+  password = "password"
+  members  = [ctfd_user.ctfer.id]
+  captain  = ctfd_user.ctfer.id
+}
+
+resource "ctfd_award" "writeup" {
+  user_id     = ctfd_user.ctfer.id
+  name        = "Best write-up"
+  description = "For the outstanding write-up of the web challenge."
+  value       = 50
+  category    = "write-up"
+  icon        = "crown"
+}
+
+resource "ctfd_award" "penalty" {
+  team_id     = ctfd_team.ctfer.id
+  name        = "Rule break"
+  description = "Flag sharing with another team."
+  value       = -100
+  icon        = "ban"
+}
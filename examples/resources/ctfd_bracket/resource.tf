@@ -0,0 +1,20 @@
+resource "ctfd_bracket" "students" {
+  name        = "Students"
+  description = "For students from any school or university."
+  type        = "teams"
+}
+
+resource "ctfd_user" "ctfer" {
+  name     = "CTFer"
+  email    = "ctfer-io@protonmail.com"
+  password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+  name       = "CTFer"
+  email      = "ctfer-io@protonmail.com"
+  password   = "password"
+  members    = [ctfd_user.ctfer.id]
+  captain    = ctfd_user.ctfer.id
+  bracket_id = ctfd_bracket.students.id
+}
@@ -0,0 +1,20 @@
+resource "ctfd_challenge" "quiz" {
+  name        = "My Quiz"
+  category    = "misc"
+  description = "..."
+  value       = 100
+  type        = "manual"
+
+  # Attributes specific to the challenge type, as defined by its CTFd plugin
+  extra = {
+    some_plugin_attribute = "some value"
+  }
+
+  topics = [
+    "Misc"
+  ]
+  tags = [
+    "misc",
+    "quiz"
+  ]
+}
@@ -0,0 +1,20 @@
+resource "ctfd_challenge_multiple_choice" "quiz" {
+  name        = "My Quiz"
+  category    = "network"
+  description = "Which port does HTTPS listen on by default ?"
+  value       = 50
+
+  choices = [
+    { text = "80" },
+    { text = "443", correct = true },
+    { text = "8443" },
+  ]
+
+  topics = [
+    "Network"
+  ]
+  tags = [
+    "network",
+    "quiz"
+  ]
+}
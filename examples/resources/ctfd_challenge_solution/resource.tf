@@ -0,0 +1,18 @@
+resource "ctfd_challenge_standard" "http" {
+  name        = "My Challenge"
+  category    = "misc"
+  description = "..."
+  value       = 500
+  state       = "visible"
+}
+
+resource "ctfd_flag" "http_flag" {
+  challenge_id = ctfd_challenge_standard.http.id
+  content      = "CTF{some_flag}"
+}
+
+resource "ctfd_challenge_solution" "http_solution" {
+  challenge_id = ctfd_challenge_standard.http.id
+  content      = file("${path.module}/writeup.md")
+  state        = "solved"
+}
@@ -0,0 +1,2 @@
+# Import by <type>/<target_id>/<comment_id>, with type one of challenge, user, team or page
+terraform import ctfd_comment.http_review challenge/1/2
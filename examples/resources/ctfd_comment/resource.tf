@@ -0,0 +1,11 @@
+resource "ctfd_challenge_standard" "http" {
+  name        = "My Challenge"
+  category    = "misc"
+  description = "..."
+  value       = 500
+}
+
+resource "ctfd_comment" "http_review" {
+  challenge_id = ctfd_challenge_standard.http.id
+  content      = "Tested by CTFer on 2026-10-16."
+}
@@ -0,0 +1,2 @@
+# The configuration is a singleton, whatever the ID
+terraform import ctfd_config.event config
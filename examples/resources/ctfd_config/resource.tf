@@ -0,0 +1,15 @@
+resource "ctfd_config" "event" {
+  name                    = "CTFer.io 2026"
+  description             = "The yearly CTF of the CTFer.io community."
+  start                   = "2026-10-16T08:00:00Z"
+  end                     = "2026-10-17T20:00:00Z"
+  user_mode               = "teams"
+  team_size               = 4
+  registration_visibility = "public"
+  score_visibility        = "public"
+  theme_footer            = "<p>Powered by CTFer.io</p>"
+
+  extra = {
+    "social_shares" = "false"
+  }
+}
@@ -0,0 +1,25 @@
+resource "ctfd_field" "school" {
+  name        = "School"
+  description = "The school you are studying at, if any."
+  editable    = true
+  public      = true
+  scope       = "user"
+}
+
+resource "ctfd_field" "student" {
+  name     = "Student"
+  type     = "boolean"
+  required = true
+  scope    = "user"
+}
+
+resource "ctfd_user" "ctfer" {
+  name     = "CTFer"
+  email    = "ctfer-io@protonmail.com"
+  password = "password"
+
+  fields = {
+    (ctfd_field.school.id)  = "Grenoble INP - Ensimag"
+    (ctfd_field.student.id) = "true"
+  }
+}
@@ -0,0 +1,26 @@
+resource "ctfd_notification" "flag_format" {
+  title   = "Flag format"
+  content = "Reminder: flags are in the format `CTF{...}`."
+  type    = "alert"
+}
+
+resource "ctfd_user" "ctfer" {
+  name     = "CTFer"
+  email    = "ctfer-io@protonmail.com"
+  password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+  name     = "CTFer"
+  email    = "ctfer-io@protonmail.com"
+  password = "password"
+  members  = [ctfd_user.ctfer.id]
+  captain  = ctfd_user.ctfer.id
+}
+
+resource "ctfd_notification" "fixed" {
+  title   = "Challenge fixed"
+  content = "Your report on the web challenge was right, it is now fixed. Thanks !"
+  team_id = ctfd_team.ctfer.id
+  sound   = false
+}
@@ -0,0 +1,5 @@
+# Import by ID
+terraform import ctfd_page.rules 1
+
+# Import by route
+terraform import ctfd_page.rules rules
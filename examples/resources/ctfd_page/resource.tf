@@ -0,0 +1,18 @@
+resource "ctfd_page" "rules" {
+  title   = "Rules"
+  route   = "rules"
+  content = <<-EOT
+    # Rules
+
+    - Do not attack the infrastructure.
+    - Do not share flags between teams.
+  EOT
+}
+
+resource "ctfd_page" "sponsors" {
+  title         = "Sponsors"
+  route         = "sponsors"
+  format        = "html"
+  content       = "<h1>Thanks to our sponsors !</h1>"
+  auth_required = true
+}
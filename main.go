@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -34,6 +35,14 @@ func main() {
 
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
 
+	// Release what was kept alive for the run, e.g. short-lived API tokens.
+	// Terraform kills the provider about 2 seconds after asking it to stop.
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	if serr := provider.Shutdown(ctx); serr != nil {
+		log.Print(serr.Error())
+	}
+	cancel()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}
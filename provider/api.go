@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/transport"
+)
+
+// The following helpers complete github.com/ctfer-io/go-ctfd/api for the
+// CTFd endpoints (or parameters) it does not support yet.
+// They mimic its internal behaviour: the request is issued through
+// *api.Client.Do, which handles the base URL and the authentication,
+// then the CTFd response is decoded and its errors are handled.
+
+func apiGet(ctx context.Context, client *api.Client, edp string, dst any) error {
+	return apiCall(ctx, client, http.MethodGet, edp, nil, dst)
+}
+
+func apiPost(ctx context.Context, client *api.Client, edp string, params, dst any) error {
+	return apiCall(ctx, client, http.MethodPost, edp, params, dst)
+}
+
+func apiPatch(ctx context.Context, client *api.Client, edp string, params, dst any) error {
+	return apiCall(ctx, client, http.MethodPatch, edp, params, dst)
+}
+
+func apiDelete(ctx context.Context, client *api.Client, edp string) error {
+	return apiCall(ctx, client, http.MethodDelete, edp, nil, nil)
+}
+
+// apiGetPage gets a page of a paginated CTFd endpoint, and returns the
+// number of the next one if any.
+func apiGetPage(ctx context.Context, client *api.Client, edp string, dst any) (*int, error) {
+	meta, err := apiDo(ctx, client, http.MethodGet, edp, nil, dst)
+	if err != nil || meta == nil {
+		return nil, err
+	}
+	return meta.Pagination.Next, nil
+}
+
+func apiCall(ctx context.Context, client *api.Client, method, edp string, params, dst any) error {
+	_, err := apiDo(ctx, client, method, edp, params, dst)
+	return err
+}
+
+// responseMeta is the metadata CTFd returns along with the paginated responses.
+type responseMeta struct {
+	Pagination struct {
+		Next *int `json:"next"`
+	} `json:"pagination"`
+}
+
+func apiDo(ctx context.Context, client *api.Client, method, edp string, params, dst any) (*responseMeta, error) {
+	var body io.Reader
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, "/api/v1"+edp, body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Decode response
+	resp := struct {
+		api.Response
+		Meta *responseMeta `json:"meta,omitempty"`
+	}{
+		Response: api.Response{
+			Data: dst,
+		},
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("CTFd responded with invalid JSON for content: %w", err)
+	}
+
+	// Handle errors if any
+	if resp.Errors != nil {
+		return nil, fmt.Errorf("CTFd responded with errors: %v", resp.Errors)
+	}
+	if !resp.Success {
+		if resp.Message != nil {
+			return nil, fmt.Errorf("CTFd responded with no success but no error, got message: %s", *resp.Message)
+		}
+		return nil, errors.New("CTFd responded with no success but no error, and no message")
+	}
+	return resp.Meta, nil
+}
+
+// isNotFound returns whether err is due to CTFd responding 404 Not Found,
+// e.g. as the object was deleted out of band.
+func isNotFound(err error) bool {
+	var nf *transport.NotFoundError
+	return errors.As(err, &nf)
+}
+
+// ctfdString returns the string representation of a loosely typed CTFd value,
+// such as configuration or field values, and false if it is not set (null).
+func ctfdString(raw any) (string, bool) {
+	switch v := raw.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return fmt.Sprintf("%v", raw), true
+}
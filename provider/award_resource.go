@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*awardResource)(nil)
+	_ resource.ResourceWithConfigure      = (*awardResource)(nil)
+	_ resource.ResourceWithImportState    = (*awardResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*awardResource)(nil)
+)
+
+func NewAwardResource() resource.Resource {
+	return &awardResource{}
+}
+
+type awardResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+type awardResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	UserID      types.String `tfsdk:"user_id"`
+	TeamID      types.String `tfsdk:"team_id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Value       types.Int64  `tfsdk:"value"`
+	Category    types.String `tfsdk:"category"`
+	Icon        types.String `tfsdk:"icon"`
+}
+
+func (r *awardResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_award"
+}
+
+func (r *awardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An award grants points to a user or a team, for instance for a write-up. With a negative value, it is a penalty (e.g. for a rule break). CTFd does not permit updating an award, so any change requires full replacement.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the award, used internally to handle the CTFd corresponding object.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "User who receives the award. If not set, defaults to the captain of the team. At least one of `user_id` and `team_id` must be set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"team_id": schema.StringAttribute{
+				MarkdownDescription: "Team who receives the award. In teams mode, if not set, defaults to the team of the user. At least one of `user_id` and `team_id` must be set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the award, as displayed to the end-users.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the award, e.g. the reason it was granted.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.Int64Attribute{
+				MarkdownDescription: "The value (points) of the award. Could be negative for a penalty.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"category": schema.StringAttribute{
+				MarkdownDescription: "Category of the award.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"icon": schema.StringAttribute{
+				MarkdownDescription: "Icon of the award as defined by the CTFd theme (e.g. `shield`, `bug`, `crown`, `crosshairs`, `ban`, `lightning`, `skull`, `brain`, `code`, `cowboy` or `angry` for the core theme).",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *awardResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data awardResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.UserID.IsNull() && data.TeamID.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_id"),
+			"Missing award target",
+			"An award must target a user, a team, or both. Please set at least one of user_id and team_id.",
+		)
+	}
+}
+
+func (r *awardResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+// postAwardsParams completes api.PostAwardsParams with the team
+// target, as CTFd only infers it from the user in teams mode.
+type postAwardsParams struct {
+	api.PostAwardsParams
+
+	TeamID *int `json:"team_id,omitempty"`
+}
+
+func (r *awardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data awardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := &postAwardsParams{
+		PostAwardsParams: api.PostAwardsParams{
+			Name:        data.Name.ValueString(),
+			Description: data.Description.ValueString(),
+			Value:       int(data.Value.ValueInt64()),
+			Category:    data.Category.ValueString(),
+			Icon:        data.Icon.ValueString(),
+		},
+	}
+	if !data.TeamID.IsUnknown() && !data.TeamID.IsNull() {
+		params.TeamID = utils.Ptr(utils.Atoi(data.TeamID.ValueString()))
+	}
+
+	// CTFd requires a user for every award, so when only the team is
+	// targeted its captain receives it.
+	if data.UserID.IsUnknown() || data.UserID.IsNull() {
+		if params.TeamID == nil {
+			resp.Diagnostics.AddError(
+				"Provider Error",
+				"An award must target a user, a team, or both, but none were defined.",
+			)
+			return
+		}
+		team, err := r.client.GetTeam(*params.TeamID, api.WithContext(ctx))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to read team %d, got error: %s", *params.TeamID, err),
+			)
+			return
+		}
+		if team.CaptainID == nil {
+			resp.Diagnostics.AddError(
+				"CTFd Error",
+				fmt.Sprintf("Team %d has no captain to receive the award, please define the user_id.", *params.TeamID),
+			)
+			return
+		}
+		params.UserID = *team.CaptainID
+	} else {
+		params.UserID = utils.Atoi(data.UserID.ValueString())
+	}
+
+	// Create award
+	res := &api.Award{}
+	if err := apiPost(ctx, r.client, "/awards", params, res); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create award, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created an award")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+	data.UserID = types.StringValue(strconv.Itoa(res.UserID))
+	data.TeamID = types.StringNull()
+	if res.TeamID != 0 {
+		data.TeamID = types.StringValue(strconv.Itoa(res.TeamID))
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *awardResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data awardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve award
+	res, err := r.client.GetAward(data.ID.ValueString(), api.WithContext(ctx))
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read award %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Upsert values
+	data.UserID = types.StringValue(strconv.Itoa(res.UserID))
+	data.TeamID = types.StringNull()
+	if res.TeamID != 0 {
+		data.TeamID = types.StringValue(strconv.Itoa(res.TeamID))
+	}
+	data.Name = types.StringValue(res.Name)
+	data.Description = types.StringValue("")
+	if res.Description != nil {
+		data.Description = types.StringValue(*res.Description)
+	}
+	data.Value = types.Int64Value(int64(res.Value))
+	data.Category = types.StringValue("")
+	if res.Category != nil {
+		data.Category = types.StringValue(*res.Category)
+	}
+	data.Icon = types.StringValue(res.Icon)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *awardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data awardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddError("Provider Error", "CTFd does not permit update of award-related information thus this provider cannot do so. This operation should not have been possible.")
+}
+
+func (r *awardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data awardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteAward(data.ID.ValueString(), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete award %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *awardResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
@@ -0,0 +1,100 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Award_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer awarded"
+	email    = "ctfer-io-award@protonmail.com"
+	password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+	name     = "CTFer awarded"
+	email    = "ctfer-io-award@protonmail.com"
+	password = "password"
+	members  = [ctfd_user.ctfer.id]
+	captain  = ctfd_user.ctfer.id
+}
+
+resource "ctfd_award" "writeup" {
+	user_id     = ctfd_user.ctfer.id
+	name        = "Best write-up"
+	description = "For the outstanding write-up of the web challenge."
+	value       = 50
+	category    = "write-up"
+	icon        = "crown"
+
+	depends_on = [ctfd_team.ctfer]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_award.writeup", "id"),
+					// In teams mode, the team is inferred from the user
+					resource.TestCheckResourceAttrPair("ctfd_award.writeup", "team_id", "ctfd_team.ctfer", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfd_award.writeup",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer awarded"
+	email    = "ctfer-io-award@protonmail.com"
+	password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+	name     = "CTFer awarded"
+	email    = "ctfer-io-award@protonmail.com"
+	password = "password"
+	members  = [ctfd_user.ctfer.id]
+	captain  = ctfd_user.ctfer.id
+}
+
+resource "ctfd_award" "writeup" {
+	user_id     = ctfd_user.ctfer.id
+	name        = "Best write-up"
+	description = "For the outstanding write-up of the web challenge."
+	value       = 100
+	category    = "write-up"
+	icon        = "crown"
+
+	depends_on = [ctfd_team.ctfer]
+}
+
+resource "ctfd_award" "penalty" {
+	team_id     = ctfd_team.ctfer.id
+	name        = "Rule break"
+	description = "Flag sharing with another team."
+	value       = -100
+	icon        = "ban"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_award.writeup", "value", "100"),
+					resource.TestCheckResourceAttr("ctfd_award.penalty", "value", "-100"),
+					// The captain receives the award when only the team is targeted
+					resource.TestCheckResourceAttrPair("ctfd_award.penalty", "user_id", "ctfd_team.ctfer", "captain"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*bracketResource)(nil)
+	_ resource.ResourceWithConfigure   = (*bracketResource)(nil)
+	_ resource.ResourceWithImportState = (*bracketResource)(nil)
+)
+
+func NewBracketResource() resource.Resource {
+	return &bracketResource{}
+}
+
+type bracketResource struct {
+	client   *api.Client
+	version  *ctfdVersion
+	readOnly bool
+}
+
+type bracketResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+}
+
+func (r *bracketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bracket"
+}
+
+func (r *bracketResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A bracket splits the scoreboard, for instance between students and professionals. Users or teams are then assigned to it through their `bracket_id`. Requires CTFd 3.7 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the bracket, used internally to handle the CTFd corresponding object.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the bracket, as displayed on the scoreboard.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the bracket, e.g. who it is intended for.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of accounts the bracket applies to, either users or teams. Changing it requires full replacement.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.NewStringEnumValidator([]basetypes.StringValue{
+						types.StringValue("users"),
+						types.StringValue("teams"),
+					}),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *bracketResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.version = data.Version
+	r.readOnly = data.ReadOnly
+}
+
+func (r *bracketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data bracketResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	requireVersion(&resp.Diagnostics, r.version, "Brackets", 3, 7)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create bracket
+	res, err := r.client.PostBrackets(&api.PostBracketsParams{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		Type:        data.Type.ValueString(),
+	}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create bracket, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a bracket")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *bracketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data bracketResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve bracket, CTFd does not expose them one by one
+	bks, err := r.client.GetBrackets(&api.GetBracketsParams{}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read brackets, got error: %s", err),
+		)
+		return
+	}
+	var res *api.Bracket
+	for _, bk := range bks {
+		if strconv.Itoa(bk.ID) == data.ID.ValueString() {
+			res = bk
+			break
+		}
+	}
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Upsert values
+	data.Name = types.StringValue(res.Name)
+	data.Description = types.StringValue(res.Description)
+	data.Type = types.StringValue(res.Type)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// patchBracketParams is not supported by github.com/ctfer-io/go-ctfd/api.
+type patchBracketParams struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (r *bracketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data bracketResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update bracket
+	if err := apiPatch(ctx, r.client, "/brackets/"+data.ID.ValueString(), &patchBracketParams{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	}, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update bracket %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *bracketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data bracketResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Users and teams of the bracket are not deleted, CTFd unassigns them
+	if err := apiDelete(ctx, r.client, "/brackets/"+data.ID.ValueString()); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete bracket %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *bracketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
+
+// bracketID returns the bracket to send to CTFd for a user or a team,
+// or nil if it is not part of any.
+func bracketID(id types.String) *int {
+	if id.IsNull() || id.IsUnknown() {
+		return nil
+	}
+	return utils.Ptr(utils.Atoi(id.ValueString()))
+}
@@ -0,0 +1,86 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Bracket_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_bracket" "students" {
+	name = "Students"
+	type = "teams"
+}
+
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer"
+	email    = "ctfer-io-bracket@protonmail.com"
+	password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+	name       = "CTFer"
+	email      = "ctfer-io-bracket-team@protonmail.com"
+	password   = "password"
+	members    = [ctfd_user.ctfer.id]
+	captain    = ctfd_user.ctfer.id
+	bracket_id = ctfd_bracket.students.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_bracket.students", "id"),
+					resource.TestCheckResourceAttr("ctfd_bracket.students", "description", ""),
+					resource.TestCheckResourceAttrPair("ctfd_team.ctfer", "bracket_id", "ctfd_bracket.students", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfd_bracket.students",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_bracket" "students" {
+	name        = "Students"
+	description = "For students from any school or university."
+	type        = "teams"
+}
+
+resource "ctfd_bracket" "professionals" {
+	name = "Professionals"
+	type = "teams"
+}
+
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer"
+	email    = "ctfer-io-bracket@protonmail.com"
+	password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+	name       = "CTFer"
+	email      = "ctfer-io-bracket-team@protonmail.com"
+	password   = "password"
+	members    = [ctfd_user.ctfer.id]
+	captain    = ctfd_user.ctfer.id
+	bracket_id = ctfd_bracket.professionals.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_bracket.students", "description", "For students from any school or university."),
+					resource.TestCheckResourceAttrPair("ctfd_team.ctfer", "bracket_id", "ctfd_bracket.professionals", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
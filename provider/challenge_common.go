@@ -1,7 +1,13 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
 	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -37,3 +43,158 @@ func FromAnon(b *bool) types.String {
 	}
 	panic("invalid anonymization value, got boolean false")
 }
+
+// GetRequirements returns the requirements of the challenge to send to CTFd,
+// or nil if there is none.
+func (chall *ChallengeStandardResourceModel) GetRequirements() *api.Requirements {
+	if chall.Requirements == nil {
+		return nil
+	}
+	preqs := make([]int, 0, len(chall.Requirements.Prerequisites))
+	for _, preq := range chall.Requirements.Prerequisites {
+		id, _ := strconv.Atoi(preq.ValueString())
+		preqs = append(preqs, id)
+	}
+	return &api.Requirements{
+		Anonymize:     GetAnon(chall.Requirements.Behavior),
+		Prerequisites: preqs,
+	}
+}
+
+// CreateSubresources creates the tags and topics of a freshly created challenge.
+func (chall *ChallengeStandardResourceModel) CreateSubresources(ctx context.Context, client *api.Client) (diags diag.Diagnostics) {
+	// Create tags
+	challTags := make([]types.String, 0, len(chall.Tags))
+	for _, tag := range chall.Tags {
+		_, err := client.PostTags(&api.PostTagsParams{
+			Challenge: utils.Atoi(chall.ID.ValueString()),
+			Value:     tag.ValueString(),
+		}, api.WithContext(ctx))
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to create tags, got error: %s", err),
+			)
+			return
+		}
+		challTags = append(challTags, tag)
+	}
+	if chall.Tags != nil {
+		chall.Tags = challTags
+	}
+
+	// Create topics
+	challTopics := make([]types.String, 0, len(chall.Topics))
+	for _, topic := range chall.Topics {
+		_, err := client.PostTopics(&api.PostTopicsParams{
+			Challenge: utils.Atoi(chall.ID.ValueString()),
+			Type:      "challenge",
+			Value:     topic.ValueString(),
+		}, api.WithContext(ctx))
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to create topic, got error: %s", err),
+			)
+			return
+		}
+		challTopics = append(challTopics, topic)
+	}
+	if chall.Topics != nil {
+		chall.Topics = challTopics
+	}
+	return
+}
+
+// UpdateSubresources updates the tags and topics of an existing challenge.
+// Only the missing ones are created and the outdated ones deleted, such that
+// the challenge is never left without its tags nor topics.
+func (chall *ChallengeStandardResourceModel) UpdateSubresources(ctx context.Context, client *api.Client) (diags diag.Diagnostics) {
+	id := utils.Atoi(chall.ID.ValueString())
+
+	// Update its tags
+	challTags, err := client.GetChallengeTags(id, api.WithContext(ctx))
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get all tags of challenge %s, got error: %s", chall.ID.ValueString(), err),
+		)
+		return
+	}
+	current := make([]string, 0, len(challTags))
+	for _, tag := range challTags {
+		current = append(current, tag.Value)
+	}
+	add, remove := utils.Diff(current, stringValues(chall.Tags))
+	for _, tag := range add {
+		if _, err := client.PostTags(&api.PostTagsParams{
+			Challenge: id,
+			Value:     tag,
+		}, api.WithContext(ctx)); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to create tag of challenge %s, got error: %s", chall.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+	for _, i := range remove {
+		if err := client.DeleteTag(strconv.Itoa(challTags[i].ID), api.WithContext(ctx)); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to delete tag %d of challenge %s, got error: %s", challTags[i].ID, chall.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	// Update its topics
+	challTopics, err := client.GetChallengeTopics(id, api.WithContext(ctx))
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to get all topics of challenge %s, got error: %s", chall.ID.ValueString(), err),
+		)
+		return
+	}
+	current = make([]string, 0, len(challTopics))
+	for _, topic := range challTopics {
+		current = append(current, topic.Value)
+	}
+	add, remove = utils.Diff(current, stringValues(chall.Topics))
+	for _, topic := range add {
+		if _, err := client.PostTopics(&api.PostTopicsParams{
+			Challenge: id,
+			Type:      "challenge",
+			Value:     topic,
+		}, api.WithContext(ctx)); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to create topic of challenge %s, got error: %s", chall.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+	for _, i := range remove {
+		if err := client.DeleteTopic(&api.DeleteTopicArgs{
+			ID:   strconv.Itoa(challTopics[i].ID),
+			Type: "challenge",
+		}, api.WithContext(ctx)); err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to delete topic %d of challenge %s, got error: %s", challTopics[i].ID, chall.ID.ValueString(), err),
+			)
+			return
+		}
+	}
+	return
+}
+
+// stringValues returns the values of strs.
+func stringValues(strs []types.String) []string {
+	values := make([]string, 0, len(strs))
+	for _, str := range strs {
+		values = append(values, str.ValueString())
+	}
+	return values
+}
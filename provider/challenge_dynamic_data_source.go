@@ -91,7 +91,7 @@ func (ch *challengeDynamicDataSource) Schema(ctx context.Context, req datasource
 									MarkdownDescription: "Behavior if not unlocked, either hidden or anonymized.",
 									Computed:            true,
 								},
-								"prerequisites": schema.ListAttribute{
+								"prerequisites": schema.SetAttribute{
 
 									MarkdownDescription: "List of the challenges ID.",
 									Computed:            true,
@@ -99,12 +99,12 @@ func (ch *challengeDynamicDataSource) Schema(ctx context.Context, req datasource
 								},
 							},
 						},
-						"tags": schema.ListAttribute{
+						"tags": schema.SetAttribute{
 							MarkdownDescription: "List of challenge tags that will be displayed to the end-user. You could use them to give some quick insights of what a challenge involves.",
 							ElementType:         types.StringType,
 							Computed:            true,
 						},
-						"topics": schema.ListAttribute{
+						"topics": schema.SetAttribute{
 							MarkdownDescription: "List of challenge topics that are displayed to the administrators for maintenance and planification.",
 							ElementType:         types.StringType,
 							Computed:            true,
@@ -121,16 +121,16 @@ func (ch *challengeDynamicDataSource) Configure(ctx context.Context, req datasou
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	ch.client = client
+	ch.client = data.Client
 }
 
 func (ch *challengeDynamicDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -151,10 +151,14 @@ func (ch *challengeDynamicDataSource) Read(ctx context.Context, req datasource.R
 	for _, c := range challs {
 		chall := ChallengeDynamicResourceModel{}
 		chall.ID = types.StringValue(strconv.Itoa(c.ID))
-		chall.Read(ctx, ch.client, resp.Diagnostics)
+		resp.Diagnostics.Append(chall.Read(ctx, ch.client)...)
 		if resp.Diagnostics.HasError() {
 			return
 		}
+		if chall.ID.IsNull() {
+			// Deleted since listed
+			continue
+		}
 
 		state.Challenges = append(state.Challenges, chall)
 	}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/ctfer-io/go-ctfd/api"
@@ -21,9 +22,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*challengeDynamicResource)(nil)
-	_ resource.ResourceWithConfigure   = (*challengeDynamicResource)(nil)
-	_ resource.ResourceWithImportState = (*challengeDynamicResource)(nil)
+	_ resource.Resource                 = (*challengeDynamicResource)(nil)
+	_ resource.ResourceWithConfigure    = (*challengeDynamicResource)(nil)
+	_ resource.ResourceWithImportState  = (*challengeDynamicResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*challengeDynamicResource)(nil)
 )
 
 func NewChallengeDynamicResource() resource.Resource {
@@ -31,7 +33,8 @@ func NewChallengeDynamicResource() resource.Resource {
 }
 
 type challengeDynamicResource struct {
-	client *api.Client
+	client   *api.Client
+	readOnly bool
 }
 
 // ChallengeDynamicResourceModel is exported for ease of extending
@@ -52,7 +55,10 @@ func (r *challengeDynamicResource) Metadata(ctx context.Context, req resource.Me
 func (r *challengeDynamicResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "CTFd is built around the Challenge resource, which contains all the attributes to define a part of the Capture The Flag event.\n\nThis implementation has support of a more dynamic behavior for its scoring through time/solves thus is different from a standard challenge.",
-		Attributes:          ChallengeDynamicResourceAttributes,
+		// Version 1 turned tags, topics and requirements.prerequisites from
+		// lists into sets.
+		Version:    1,
+		Attributes: ChallengeDynamicResourceAttributes,
 	}
 }
 
@@ -62,19 +68,25 @@ func (r *challengeDynamicResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
 }
 
 func (r *challengeDynamicResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data ChallengeDynamicResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -82,18 +94,6 @@ func (r *challengeDynamicResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// Create Challenge
-	reqs := (*api.Requirements)(nil)
-	if data.Requirements != nil {
-		preqs := make([]int, 0, len(data.Requirements.Prerequisites))
-		for _, preq := range data.Requirements.Prerequisites {
-			id, _ := strconv.Atoi(preq.ValueString())
-			preqs = append(preqs, id)
-		}
-		reqs = &api.Requirements{
-			Anonymize:     GetAnon(data.Requirements.Behavior),
-			Prerequisites: preqs,
-		}
-	}
 	res, err := r.client.PostChallenges(&api.PostChallengesParams{
 		Name:           data.Name.ValueString(),
 		Category:       data.Category.ValueString(),
@@ -108,7 +108,7 @@ func (r *challengeDynamicResource) Create(ctx context.Context, req resource.Crea
 		State:          data.State.ValueString(),
 		Type:           "dynamic",
 		NextID:         utils.ToInt(data.Next),
-		Requirements:   reqs,
+		Requirements:   data.GetRequirements(),
 	}, api.WithContext(ctx))
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -123,48 +123,13 @@ func (r *challengeDynamicResource) Create(ctx context.Context, req resource.Crea
 	// Save computed attributes in state
 	data.ID = types.StringValue(strconv.Itoa(res.ID))
 
-	// Create tags
-	challTags := make([]types.String, 0, len(data.Tags))
-	for _, tag := range data.Tags {
-		_, err := r.client.PostTags(&api.PostTagsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Value:     tag.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create tags, got error: %s", err),
-			)
-			return
-		}
-		challTags = append(challTags, tag)
-	}
-	if data.Tags != nil {
-		data.Tags = challTags
-	}
-
-	// Create topics
-	challTopics := make([]types.String, 0, len(data.Topics))
-	for _, topic := range data.Topics {
-		_, err := r.client.PostTopics(&api.PostTopicsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Type:      "challenge",
-			Value:     topic.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create topic, got error: %s", err),
-			)
-			return
-		}
-		challTopics = append(challTopics, topic)
-	}
-	if data.Topics != nil {
-		data.Topics = challTopics
-	}
+	// Create subresources
+	resp.Diagnostics.Append(data.CreateSubresources(ctx, r.client)...)
 
 	if resp.Diagnostics.HasError() {
+		rollbackCreate(ctx, resp, "Challenge", data.ID.ValueString(), func(ctx context.Context) error {
+			return r.client.DeleteChallenge(res.ID, api.WithContext(ctx))
+		})
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -177,36 +142,36 @@ func (r *challengeDynamicResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	data.Read(ctx, r.client, resp.Diagnostics)
-
+	resp.Diagnostics.Append(data.Read(ctx, r.client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if data.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *challengeDynamicResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data ChallengeDynamicResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	var dataState ChallengeDynamicResourceModel
-	req.State.Get(ctx, &dataState)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Patch direct attributes
-	reqs := (*api.Requirements)(nil)
-	if data.Requirements != nil {
-		preqs := make([]int, 0, len(data.Requirements.Prerequisites))
-		for _, preq := range data.Requirements.Prerequisites {
-			id, _ := strconv.Atoi(preq.ValueString())
-			preqs = append(preqs, id)
-		}
-		reqs = &api.Requirements{
-			Anonymize:     GetAnon(data.Requirements.Behavior),
-			Prerequisites: preqs,
-		}
-	}
 	_, err := r.client.PatchChallenge(utils.Atoi(data.ID.ValueString()), &api.PatchChallengeParams{
 		Name:           data.Name.ValueString(),
 		Category:       data.Category.ValueString(),
@@ -220,7 +185,7 @@ func (r *challengeDynamicResource) Update(ctx context.Context, req resource.Upda
 		Minimum:        utils.ToInt(data.Minimum),
 		State:          data.State.ValueString(),
 		NextID:         utils.ToInt(data.Next),
-		Requirements:   reqs,
+		Requirements:   data.GetRequirements(),
 	}, api.WithContext(ctx))
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -230,83 +195,8 @@ func (r *challengeDynamicResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	// Update its tags (drop them all, create new ones)
-	challTags, err := r.client.GetChallengeTags(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to get all tags of challenge %s, got error: %s", data.ID.ValueString(), err),
-		)
-		return
-	}
-	for _, tag := range challTags {
-		if err := r.client.DeleteTag(strconv.Itoa(tag.ID), api.WithContext(ctx)); err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to delete tag %d of challenge %s, got error: %s", tag.ID, data.ID.ValueString(), err),
-			)
-			return
-		}
-	}
-	tags := make([]types.String, 0, len(data.Tags))
-	for _, tag := range data.Tags {
-		_, err := r.client.PostTags(&api.PostTagsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Value:     tag.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create tag of challenge %s, got error: %s", data.ID.ValueString(), err),
-			)
-			return
-		}
-		tags = append(tags, tag)
-	}
-	if data.Tags != nil {
-		data.Tags = tags
-	}
-
-	// Update its topics (drop them all, create new ones)
-	challTopics, err := r.client.GetChallengeTopics(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to get all topics of challenge %s, got error: %s", data.ID.ValueString(), err),
-		)
-		return
-	}
-	for _, topic := range challTopics {
-		if err := r.client.DeleteTopic(&api.DeleteTopicArgs{
-			ID:   strconv.Itoa(topic.ID),
-			Type: "challenge",
-		}, api.WithContext(ctx)); err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to delete topic %d of challenge %s, got error: %s", topic.ID, data.ID.ValueString(), err),
-			)
-			return
-		}
-	}
-	topics := make([]types.String, 0, len(data.Topics))
-	for _, topic := range data.Topics {
-		_, err := r.client.PostTopics(&api.PostTopicsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Type:      "challenge",
-			Value:     topic.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create topic of challenge %s, got error: %s", data.ID.ValueString(), err),
-			)
-			return
-		}
-		topics = append(topics, topic)
-	}
-	if data.Topics != nil {
-		data.Topics = topics
-	}
+	// Update subresources
+	resp.Diagnostics.Append(data.UpdateSubresources(ctx, r.client)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -315,6 +205,11 @@ func (r *challengeDynamicResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *challengeDynamicResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data ChallengeDynamicResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -322,6 +217,10 @@ func (r *challengeDynamicResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 	if err := r.client.DeleteChallenge(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete challenge, got error: %s", err))
 		return
 	}
@@ -335,13 +234,25 @@ func (r *challengeDynamicResource) ImportState(ctx context.Context, req resource
 	// Automatically call r.Read
 }
 
+func (r *challengeDynamicResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	resp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, resp)
+	return setsUpgraders(resp.Schema)
+}
+
 //
 // Starting from this are helper or types-specific code related to the ctfd_challenge_dynamic resource
 //
 
-func (chall *ChallengeDynamicResourceModel) Read(ctx context.Context, client *api.Client, diags diag.Diagnostics) {
+// Read refreshes the challenge from CTFd. Its ID is set to null if the
+// challenge does not exist anymore.
+func (chall *ChallengeDynamicResourceModel) Read(ctx context.Context, client *api.Client) (diags diag.Diagnostics) {
 	res, err := client.GetChallenge(utils.Atoi(chall.ID.ValueString()), api.WithContext(ctx))
 	if err != nil {
+		if isNotFound(err) {
+			chall.ID = types.StringNull()
+			return
+		}
 		diags.AddError("Client Error", fmt.Sprintf("Unable to read challenge %s, got error: %s", chall.ID.ValueString(), err))
 		return
 	}
@@ -394,7 +305,10 @@ func (chall *ChallengeDynamicResourceModel) Read(ctx context.Context, client *ap
 	}
 	chall.Tags = make([]basetypes.StringValue, 0, len(resTags))
 	for _, tag := range resTags {
-		chall.Tags = append(chall.Tags, types.StringValue(tag.Value))
+		// CTFd accepts the same tag many times, but it is only kept once in the set
+		if !slices.Contains(chall.Tags, types.StringValue(tag.Value)) {
+			chall.Tags = append(chall.Tags, types.StringValue(tag.Value))
+		}
 	}
 
 	// => Topics
@@ -408,8 +322,12 @@ func (chall *ChallengeDynamicResourceModel) Read(ctx context.Context, client *ap
 	}
 	chall.Topics = make([]basetypes.StringValue, 0, len(resTopics))
 	for _, topic := range resTopics {
-		chall.Topics = append(chall.Topics, types.StringValue(topic.Value))
+		// CTFd accepts the same topic many times, but it is only kept once in the set
+		if !slices.Contains(chall.Topics, types.StringValue(topic.Value)) {
+			chall.Topics = append(chall.Topics, types.StringValue(topic.Value))
+		}
 	}
+	return
 }
 
 var (
@@ -0,0 +1,451 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*challengeMultipleChoiceResource)(nil)
+	_ resource.ResourceWithConfigure      = (*challengeMultipleChoiceResource)(nil)
+	_ resource.ResourceWithImportState    = (*challengeMultipleChoiceResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*challengeMultipleChoiceResource)(nil)
+)
+
+func NewChallengeMultipleChoiceResource() resource.Resource {
+	return &challengeMultipleChoiceResource{}
+}
+
+type challengeMultipleChoiceResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+// ChallengeMultipleChoiceResourceModel is exported for ease of extending
+// CTFd through a plugin. Under normal circumpstances, you should
+// not use it.
+type ChallengeMultipleChoiceResourceModel struct {
+	ChallengeStandardResourceModel
+
+	Choices []ChoiceSubresourceModel `tfsdk:"choices"`
+	FlagID  types.String             `tfsdk:"flag_id"`
+}
+
+type ChoiceSubresourceModel struct {
+	Text    types.String `tfsdk:"text"`
+	Correct types.Bool   `tfsdk:"correct"`
+}
+
+func (r *challengeMultipleChoiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge_multiple_choice"
+}
+
+func (r *challengeMultipleChoiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "CTFd is built around the Challenge resource, which contains all the attributes to define a part of the Capture The Flag event.\n\nThis implementation is a quiz-style challenge: the choices are rendered in the description with the CTFd `* ()` markup, and the correct one is the flag of the challenge.",
+		Attributes:          ChallengeMultipleChoiceResourceAttributes,
+	}
+}
+
+func (r *challengeMultipleChoiceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ChallengeMultipleChoiceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A description ending with a choice would be read back as a choice
+	desc := strings.TrimRight(data.Description.ValueString(), "\r\n")
+	if lines := strings.Split(desc, "\n"); strings.HasPrefix(lines[len(lines)-1], choicePrefix) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("description"),
+			"Invalid description",
+			fmt.Sprintf("The description must not end with a line starting with %q, as it would be read as a choice.", choicePrefix),
+		)
+	}
+
+	corrects := 0
+	for i, choice := range data.Choices {
+		if choice.Text.IsUnknown() || choice.Correct.IsUnknown() {
+			return
+		}
+		text := choice.Text.ValueString()
+		if strings.TrimSpace(text) == "" || strings.ContainsAny(text, "\r\n") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("choices").AtListIndex(i).AtName("text"),
+				"Invalid choice",
+				"A choice must be a non-empty single line of text.",
+			)
+		}
+		if choice.Correct.ValueBool() {
+			corrects++
+		}
+	}
+	if corrects != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("choices"),
+			"Invalid choices",
+			fmt.Sprintf("Exactly one choice must be correct, got %d.", corrects),
+		)
+	}
+}
+
+func (r *challengeMultipleChoiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+func (r *challengeMultipleChoiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data ChallengeMultipleChoiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create Challenge
+	res, err := r.client.PostChallenges(&api.PostChallengesParams{
+		Name:           data.Name.ValueString(),
+		Category:       data.Category.ValueString(),
+		Description:    data.RenderDescription(),
+		Attribution:    data.Attribution.ValueStringPointer(),
+		ConnectionInfo: data.ConnectionInfo.ValueStringPointer(),
+		MaxAttempts:    utils.ToInt(data.MaxAttempts),
+		Value:          int(data.Value.ValueInt64()),
+		State:          data.State.ValueString(),
+		Type:           "standard",
+		NextID:         utils.ToInt(data.Next),
+		Requirements:   data.GetRequirements(),
+	}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create challenge, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a challenge")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	// Create subresources
+	resp.Diagnostics.Append(data.CreateSubresources(ctx, r.client)...)
+	if !resp.Diagnostics.HasError() {
+		// Create the flag matching the correct choice
+		flag, err := r.client.PostFlags(&api.PostFlagsParams{
+			Challenge: res.ID,
+			Content:   data.CorrectChoice(),
+			Data:      "case_sensitive",
+			Type:      "static",
+		}, api.WithContext(ctx))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to create flag, got error: %s", err),
+			)
+		} else {
+			data.FlagID = types.StringValue(strconv.Itoa(flag.ID))
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		rollbackCreate(ctx, resp, "Challenge", data.ID.ValueString(), func(ctx context.Context) error {
+			return r.client.DeleteChallenge(res.ID, api.WithContext(ctx))
+		})
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeMultipleChoiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ChallengeMultipleChoiceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.Read(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeMultipleChoiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data ChallengeMultipleChoiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Patch direct attributes
+	_, err := r.client.PatchChallenge(utils.Atoi(data.ID.ValueString()), &api.PatchChallengeParams{
+		Name:           data.Name.ValueString(),
+		Category:       data.Category.ValueString(),
+		Description:    data.RenderDescription(),
+		Attribution:    data.Attribution.ValueStringPointer(),
+		ConnectionInfo: data.ConnectionInfo.ValueStringPointer(),
+		MaxAttempts:    utils.ToInt(data.MaxAttempts),
+		Value:          utils.ToInt(data.Value),
+		State:          data.State.ValueString(),
+		NextID:         utils.ToInt(data.Next),
+		Requirements:   data.GetRequirements(),
+	}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update challenge, got error: %s", err),
+		)
+		return
+	}
+
+	// Update subresources
+	resp.Diagnostics.Append(data.UpdateSubresources(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update the flag, or create it back if it was not found
+	if data.FlagID.IsNull() || data.FlagID.IsUnknown() {
+		flag, err := r.client.PostFlags(&api.PostFlagsParams{
+			Challenge: utils.Atoi(data.ID.ValueString()),
+			Content:   data.CorrectChoice(),
+			Data:      "case_sensitive",
+			Type:      "static",
+		}, api.WithContext(ctx))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to create flag, got error: %s", err),
+			)
+			return
+		}
+		data.FlagID = types.StringValue(strconv.Itoa(flag.ID))
+	} else {
+		if _, err := r.client.PatchFlag(data.FlagID.ValueString(), &api.PatchFlagParams{
+			ID:      data.FlagID.ValueString(),
+			Content: data.CorrectChoice(),
+			Data:    "case_sensitive",
+			Type:    "static",
+		}, api.WithContext(ctx)); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to update flag %s, got error: %s", data.FlagID.ValueString(), err),
+			)
+			return
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeMultipleChoiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data ChallengeMultipleChoiceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteChallenge(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete challenge, got error: %s", err))
+		return
+	}
+
+	// ... don't need to delete nested objects, this is handled by CTFd
+}
+
+func (r *challengeMultipleChoiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
+
+//
+// Starting from this are helper or types-specific code related to the ctfd_challenge_multiple_choice resource
+//
+
+// choicePrefix is the CTFd markup of a choice in a challenge description.
+const choicePrefix = "* () "
+
+func (chall *ChallengeMultipleChoiceResourceModel) Read(ctx context.Context, client *api.Client) (diags diag.Diagnostics) {
+	diags.Append(chall.ChallengeStandardResourceModel.Read(ctx, client)...)
+	if diags.HasError() || chall.ID.IsNull() {
+		return
+	}
+
+	// Split the choices out of the description
+	desc, texts := parseChoices(chall.Description.ValueString())
+	chall.Description = types.StringValue(desc)
+
+	// Find the flag of the correct choice: the one already known, or on
+	// import the first static flag matching a choice.
+	flags, err := client.GetChallengeFlags(utils.Atoi(chall.ID.ValueString()), api.WithContext(ctx))
+	if err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read challenge %s flags, got error: %s", chall.ID.ValueString(), err),
+		)
+		return
+	}
+	var correct *api.Flag
+	for _, flag := range flags {
+		if strconv.Itoa(flag.ID) == chall.FlagID.ValueString() {
+			correct = flag
+			break
+		}
+		if correct == nil && chall.FlagID.IsNull() && flag.Type == "static" && slices.Contains(texts, flag.Content) {
+			correct = flag
+		}
+	}
+	// A managed flag deleted out of band is drift: no choice is read back as
+	// correct, so the plan differs and Update creates the flag again.
+	chall.FlagID = types.StringNull()
+	if correct != nil {
+		chall.FlagID = types.StringValue(strconv.Itoa(correct.ID))
+	}
+
+	chall.Choices = make([]ChoiceSubresourceModel, 0, len(texts))
+	for _, text := range texts {
+		chall.Choices = append(chall.Choices, ChoiceSubresourceModel{
+			Text:    types.StringValue(text),
+			Correct: types.BoolValue(correct != nil && correct.Content == text),
+		})
+	}
+	return
+}
+
+// RenderDescription returns the description of the challenge with the
+// choices appended using the CTFd markup.
+func (chall *ChallengeMultipleChoiceResourceModel) RenderDescription() string {
+	lines := make([]string, 0, len(chall.Choices))
+	for _, choice := range chall.Choices {
+		lines = append(lines, choicePrefix+choice.Text.ValueString())
+	}
+	desc := chall.Description.ValueString()
+	if desc == "" {
+		return strings.Join(lines, "\n")
+	}
+	return desc + "\n\n" + strings.Join(lines, "\n")
+}
+
+// CorrectChoice returns the text of the correct choice.
+func (chall *ChallengeMultipleChoiceResourceModel) CorrectChoice() string {
+	for _, choice := range chall.Choices {
+		if choice.Correct.ValueBool() {
+			return choice.Text.ValueString()
+		}
+	}
+	return ""
+}
+
+// parseChoices is the reverse of RenderDescription: it splits the
+// trailing choices out of a challenge description.
+func parseChoices(raw string) (string, []string) {
+	lines := strings.Split(raw, "\n")
+	i := len(lines)
+	for i > 0 && strings.HasPrefix(lines[i-1], choicePrefix) {
+		i--
+	}
+	texts := make([]string, 0, len(lines)-i)
+	for _, line := range lines[i:] {
+		texts = append(texts, strings.TrimPrefix(line, choicePrefix))
+	}
+	if i == 0 {
+		return "", texts
+	}
+	return strings.TrimSuffix(strings.Join(lines[:i], "\n"), "\n"), texts
+}
+
+var (
+	// ChallengeMultipleChoiceResourceAttributes is exported for ease of extending
+	// CTFd through a plugin. Under normal circumpstances, you should
+	// not use it.
+	ChallengeMultipleChoiceResourceAttributes = utils.BlindMerge(ChallengeStandardResourceAttributes, map[string]schema.Attribute{
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Description of the challenge, consider using multiline descriptions for better style. The choices are appended to it, so its last line must not start with the `* () ` markup.",
+			Required:            true,
+		},
+		"choices": schema.ListNestedAttribute{
+			MarkdownDescription: "List of the choices of the quiz, in the order they are displayed. Exactly one of them must be correct.",
+			Required:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"text": schema.StringAttribute{
+						MarkdownDescription: "Text of the choice, on a single line. If correct, it is the content of the flag.",
+						Required:            true,
+					},
+					"correct": schema.BoolAttribute{
+						MarkdownDescription: "Is true if the choice is the correct one.",
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+					},
+				},
+			},
+		},
+		"flag_id": schema.StringAttribute{
+			MarkdownDescription: "Identifier of the flag matching the correct choice, managed by the resource.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	})
+)
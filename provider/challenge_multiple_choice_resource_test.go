@@ -0,0 +1,238 @@
+package provider_test
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_ChallengeMultipleChoice_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_multiple_choice" "quiz" {
+	name        = "Well-known ports"
+	category    = "network"
+	description = "Which port does HTTPS listen on by default ?"
+	value       = 50
+
+	choices = [
+		{ text = "80" },
+		{ text = "443", correct = true },
+		{ text = "8443" },
+	]
+
+	topics = [
+		"Network"
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_challenge_multiple_choice.quiz", "id"),
+					resource.TestCheckResourceAttrSet("ctfd_challenge_multiple_choice.quiz", "flag_id"),
+					resource.TestCheckResourceAttr("ctfd_challenge_multiple_choice.quiz", "description", "Which port does HTTPS listen on by default ?"),
+					resource.TestCheckResourceAttr("ctfd_challenge_multiple_choice.quiz", "choices.#", "3"),
+					resource.TestCheckResourceAttr("ctfd_challenge_multiple_choice.quiz", "choices.1.correct", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfd_challenge_multiple_choice.quiz",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_multiple_choice" "quiz" {
+	name        = "Well-known ports"
+	category    = "network"
+	description = <<-EOT
+		Which port does SSH listen on by default ?
+	EOT
+	value       = 50
+
+	choices = [
+		{ text = "21" },
+		{ text = "22", correct = true },
+		{ text = "23" },
+		{ text = "2222" },
+	]
+
+	topics = [
+		"Network"
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_challenge_multiple_choice.quiz", "description", "Which port does SSH listen on by default ?\n"),
+					resource.TestCheckResourceAttr("ctfd_challenge_multiple_choice.quiz", "choices.#", "4"),
+					resource.TestCheckResourceAttr("ctfd_challenge_multiple_choice.quiz", "choices.1.text", "22"),
+					resource.TestCheckResourceAttr("ctfd_challenge_multiple_choice.quiz", "choices.1.correct", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAcc_ChallengeMultipleChoice_InvalidChoices(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_multiple_choice" "quiz" {
+	name        = "Well-known ports"
+	category    = "network"
+	description = "Which port does HTTPS listen on by default ?"
+	value       = 50
+
+	choices = [
+		{ text = "80", correct = true },
+		{ text = "443", correct = true },
+	]
+}
+`,
+				ExpectError: regexp.MustCompile(`Exactly one choice must be correct`),
+			},
+		},
+	})
+}
+
+func TestChallengeMultipleChoice_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var tests = map[string]struct {
+		Description string
+		Choices     []string
+	}{
+		"single-line": {
+			Description: "Which port does HTTPS listen on by default ?",
+			Choices:     []string{"80", "443", "8443"},
+		},
+		"empty": {
+			Description: "",
+			Choices:     []string{"80", "443"},
+		},
+		"multiline": {
+			Description: "Which port does HTTPS listen on by default ?\n\nHint: it is well-known.",
+			Choices:     []string{"80", "443"},
+		},
+		"trailing-newlines": {
+			Description: "Which port does HTTPS listen on by default ?\n\n",
+			Choices:     []string{"80", "443"},
+		},
+		"choice-markup-inside": {
+			Description: "* () is the choice markup\nWhich port does HTTPS listen on by default ?",
+			Choices:     []string{"80", "443"},
+		},
+		"choice-spaces": {
+			Description: "Which is the HTTPS port ?",
+			Choices:     []string{" 443 ", "* () 80"},
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			chall := provider.ChallengeMultipleChoiceResourceModel{
+				ChallengeStandardResourceModel: provider.ChallengeStandardResourceModel{
+					Description: types.StringValue(tt.Description),
+				},
+			}
+			for _, text := range tt.Choices {
+				chall.Choices = append(chall.Choices, provider.ChoiceSubresourceModel{
+					Text:    types.StringValue(text),
+					Correct: types.BoolValue(false),
+				})
+			}
+
+			desc, texts := provider.ParseChoices(chall.RenderDescription())
+			if desc != tt.Description {
+				t.Errorf("expected description %q, got %q", tt.Description, desc)
+			}
+			if !slices.Equal(texts, tt.Choices) {
+				t.Errorf("expected choices %q, got %q", tt.Choices, texts)
+			}
+		})
+	}
+}
+
+func TestChallengeMultipleChoice_ValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	srv, err := testAccProtoV6ProviderFactories["ctfd"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := schema.ResourceSchemas["ctfd_challenge_multiple_choice"]
+	choiceType := s.ValueType().(tftypes.Object).AttributeTypes["choices"].(tftypes.List).ElementType
+
+	var tests = map[string]struct {
+		Description string
+		Choices     []string
+		ExpectError bool
+	}{
+		"valid": {
+			Description: "* () is the choice markup\nWhich port does HTTPS listen on by default ?",
+			Choices:     []string{"80", "443"},
+		},
+		"description-ending-with-choice": {
+			Description: "Which port does HTTPS listen on by default ?\n* () 8443\n",
+			Choices:     []string{"80", "443"},
+			ExpectError: true,
+		},
+		"multiline-choice": {
+			Description: "Which port does HTTPS listen on by default ?",
+			Choices:     []string{"80", "443\n* () 8443"},
+			ExpectError: true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			choices := make([]tftypes.Value, 0, len(tt.Choices))
+			for i, text := range tt.Choices {
+				choices = append(choices, tftypes.NewValue(choiceType, map[string]tftypes.Value{
+					"text":    tftypes.NewValue(tftypes.String, text),
+					"correct": tftypes.NewValue(tftypes.Bool, i == len(tt.Choices)-1),
+				}))
+			}
+			resp, err := srv.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: "ctfd_challenge_multiple_choice",
+				Config: objectValue(t, s, map[string]tftypes.Value{
+					"name":        tftypes.NewValue(tftypes.String, "Well-known ports"),
+					"category":    tftypes.NewValue(tftypes.String, "network"),
+					"description": tftypes.NewValue(tftypes.String, tt.Description),
+					"value":       tftypes.NewValue(tftypes.Number, 50),
+					"choices":     tftypes.NewValue(tftypes.List{ElementType: choiceType}, choices),
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ExpectError != (len(resp.Diagnostics) != 0) {
+				t.Errorf("expected error %t, got diagnostics: %v", tt.ExpectError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,540 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*challengeResource)(nil)
+	_ resource.ResourceWithConfigure      = (*challengeResource)(nil)
+	_ resource.ResourceWithImportState    = (*challengeResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*challengeResource)(nil)
+)
+
+func NewChallengeResource() resource.Resource {
+	return &challengeResource{}
+}
+
+type challengeResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+// ChallengeResourceModel is exported for ease of extending
+// CTFd through a plugin. Under normal circumpstances, you should
+// not use it.
+type ChallengeResourceModel struct {
+	ChallengeStandardResourceModel
+
+	Type  types.String  `tfsdk:"type"`
+	Extra types.Dynamic `tfsdk:"extra"`
+}
+
+func (r *challengeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge"
+}
+
+func (r *challengeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "CTFd is built around the Challenge resource, which contains all the attributes to define a part of the Capture The Flag event.\n\nThis implementation is generic, for challenge types brought by CTFd plugins. Their specific attributes are passed through `extra`.",
+		Attributes:          ChallengeResourceAttributes,
+	}
+}
+
+func (r *challengeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ChallengeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Extra.IsNull() || data.Extra.IsUnknown() || data.Extra.IsUnderlyingValueUnknown() {
+		return
+	}
+	extra, ok := dynamicAttributes(data.Extra)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra"),
+			"Invalid extra attributes",
+			"The extra attributes must be an object or a map, indexed by the CTFd challenge attribute name.",
+		)
+		return
+	}
+	for k := range extra {
+		if _, ok := challengeManagedKeys[k]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra"),
+				"Managed challenge attribute",
+				fmt.Sprintf("The challenge attribute %s is already managed by the resource, please use its attribute instead.", k),
+			)
+		}
+	}
+}
+
+func (r *challengeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+func (r *challengeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data ChallengeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create Challenge
+	params, err := withExtra(&api.PostChallengesParams{
+		Name:           data.Name.ValueString(),
+		Category:       data.Category.ValueString(),
+		Description:    data.Description.ValueString(),
+		Attribution:    data.Attribution.ValueStringPointer(),
+		ConnectionInfo: data.ConnectionInfo.ValueStringPointer(),
+		MaxAttempts:    utils.ToInt(data.MaxAttempts),
+		Value:          int(data.Value.ValueInt64()),
+		State:          data.State.ValueString(),
+		Type:           data.Type.ValueString(),
+		NextID:         utils.ToInt(data.Next),
+		Requirements:   data.GetRequirements(),
+	}, data.Extra, types.DynamicNull())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra"),
+			"Provider Error",
+			fmt.Sprintf("Unable to build challenge attributes, got error: %s", err),
+		)
+		return
+	}
+	res := &api.Challenge{}
+	if err := apiPost(ctx, r.client, "/challenges", params, res); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create challenge, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a challenge")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	// Create subresources
+	resp.Diagnostics.Append(data.CreateSubresources(ctx, r.client)...)
+
+	if resp.Diagnostics.HasError() {
+		rollbackCreate(ctx, resp, "Challenge", data.ID.ValueString(), func(ctx context.Context) error {
+			return r.client.DeleteChallenge(res.ID, api.WithContext(ctx))
+		})
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ChallengeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.Read(ctx, r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data ChallengeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var dataState ChallengeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Patch direct attributes
+	params, err := withExtra(&api.PatchChallengeParams{
+		Name:           data.Name.ValueString(),
+		Category:       data.Category.ValueString(),
+		Description:    data.Description.ValueString(),
+		Attribution:    data.Attribution.ValueStringPointer(),
+		ConnectionInfo: data.ConnectionInfo.ValueStringPointer(),
+		MaxAttempts:    utils.ToInt(data.MaxAttempts),
+		Value:          utils.ToInt(data.Value),
+		State:          data.State.ValueString(),
+		NextID:         utils.ToInt(data.Next),
+		Requirements:   data.GetRequirements(),
+	}, data.Extra, dataState.Extra)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra"),
+			"Provider Error",
+			fmt.Sprintf("Unable to build challenge attributes, got error: %s", err),
+		)
+		return
+	}
+	if err := apiPatch(ctx, r.client, fmt.Sprintf("/challenges/%s", data.ID.ValueString()), params, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update challenge, got error: %s", err),
+		)
+		return
+	}
+
+	// Update subresources
+	resp.Diagnostics.Append(data.UpdateSubresources(ctx, r.client)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data ChallengeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteChallenge(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete challenge, got error: %s", err))
+		return
+	}
+
+	// ... don't need to delete nested objects, this is handled by CTFd
+}
+
+func (r *challengeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
+
+//
+// Starting from this are helper or types-specific code related to the ctfd_challenge resource
+//
+
+// challengeManagedKeys are the CTFd challenge attributes handled by the
+// resource attributes, thus that cannot be part of the extra ones.
+var challengeManagedKeys = map[string]struct{}{
+	"id":              {},
+	"name":            {},
+	"category":        {},
+	"description":     {},
+	"attribution":     {},
+	"connection_info": {},
+	"max_attempts":    {},
+	"value":           {},
+	"state":           {},
+	"next_id":         {},
+	"requirements":    {},
+	"type":            {},
+}
+
+func (chall *ChallengeResourceModel) Read(ctx context.Context, client *api.Client) (diags diag.Diagnostics) {
+	// Get the challenge raw, as the type and the extra attributes are unknown
+	// to *api.Client.GetChallenge
+	res := map[string]any{}
+	if err := apiGet(ctx, client, "/challenges/"+chall.ID.ValueString(), &res); err != nil {
+		if isNotFound(err) {
+			chall.ID = types.StringNull()
+			return
+		}
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read challenge %s, got error: %s", chall.ID.ValueString(), err))
+		return
+	}
+	// Then decode the standard attributes out of it
+	std := &api.Challenge{}
+	b, err := json.Marshal(res)
+	if err == nil {
+		err = json.Unmarshal(b, std)
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to decode challenge %s, got error: %s", chall.ID.ValueString(), err))
+		return
+	}
+	diags.Append(chall.ChallengeStandardResourceModel.read(ctx, client, std)...)
+	if diags.HasError() {
+		return
+	}
+	if typ, ok := res["type"].(string); ok {
+		chall.Type = types.StringValue(typ)
+	}
+
+	// Only refresh the managed extra attributes, as CTFd returns many others
+	// (e.g. solves) and some plugins may not return the ones they are given.
+	if chall.Extra.IsNull() || chall.Extra.IsUnknown() || chall.Extra.IsUnderlyingValueUnknown() {
+		return
+	}
+	extra, ok := dynamicAttributes(chall.Extra)
+	if !ok {
+		return
+	}
+	changed := false
+	attrs := make(map[string]attr.Value, len(extra))
+	for k, v := range extra {
+		attrs[k] = v
+		raw, ok := res[k]
+		if !ok {
+			continue
+		}
+		cur, err := dynamicToAny(v)
+		if err == nil && jsonEqual(cur, raw) {
+			continue
+		}
+		attrs[k] = anyToValue(raw)
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	attrTypes := make(map[string]attr.Type, len(attrs))
+	for k, v := range attrs {
+		attrTypes[k] = v.Type(ctx)
+	}
+	obj, d := types.ObjectValue(attrTypes, attrs)
+	diags.Append(d...)
+	chall.Extra = types.DynamicValue(obj)
+	return
+}
+
+// withExtra merges the extra attributes of a challenge into the parameters
+// sent to CTFd. The extra attributes that are no longer defined are nulled.
+func withExtra(params any, extra, prev types.Dynamic) (map[string]any, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+
+	if prevAttrs, ok := dynamicAttributes(prev); ok {
+		for k := range prevAttrs {
+			out[k] = nil
+		}
+	}
+	if extraAttrs, ok := dynamicAttributes(extra); ok {
+		for k, v := range extraAttrs {
+			out[k], err = dynamicToAny(v)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %s: %w", k, err)
+			}
+		}
+	}
+	return out, nil
+}
+
+// dynamicAttributes returns the attributes of a dynamic value, and false
+// if it is not an object nor a map.
+func dynamicAttributes(v types.Dynamic) (map[string]attr.Value, bool) {
+	if v.IsNull() || v.IsUnknown() || v.IsUnderlyingValueNull() || v.IsUnderlyingValueUnknown() {
+		return nil, false
+	}
+	switch uv := v.UnderlyingValue().(type) {
+	case basetypes.ObjectValue:
+		return uv.Attributes(), true
+	case basetypes.MapValue:
+		return uv.Elements(), true
+	}
+	return nil, false
+}
+
+// dynamicToAny converts a Terraform value to its JSON-compatible Go representation.
+func dynamicToAny(v attr.Value) (any, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	if v.IsUnknown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+	switch uv := v.(type) {
+	case basetypes.DynamicValue:
+		return dynamicToAny(uv.UnderlyingValue())
+	case basetypes.StringValue:
+		return uv.ValueString(), nil
+	case basetypes.BoolValue:
+		return uv.ValueBool(), nil
+	case basetypes.Int64Value:
+		return uv.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return uv.ValueFloat64(), nil
+	case basetypes.NumberValue:
+		f := uv.ValueBigFloat()
+		if f.IsInt() {
+			i, _ := f.Int64()
+			return i, nil
+		}
+		f64, _ := f.Float64()
+		return f64, nil
+	case basetypes.ObjectValue:
+		return attrsToAny(uv.Attributes())
+	case basetypes.MapValue:
+		return attrsToAny(uv.Elements())
+	case basetypes.ListValue:
+		return elemsToAny(uv.Elements())
+	case basetypes.SetValue:
+		return elemsToAny(uv.Elements())
+	case basetypes.TupleValue:
+		return elemsToAny(uv.Elements())
+	}
+	return nil, fmt.Errorf("unsupported value type %s", v.Type(context.Background()))
+}
+
+func attrsToAny(attrs map[string]attr.Value) (map[string]any, error) {
+	out := make(map[string]any, len(attrs))
+	for k, v := range attrs {
+		a, err := dynamicToAny(v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = a
+	}
+	return out, nil
+}
+
+func elemsToAny(elems []attr.Value) ([]any, error) {
+	out := make([]any, 0, len(elems))
+	for _, v := range elems {
+		a, err := dynamicToAny(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// anyToValue converts a decoded JSON value to a Terraform value.
+func anyToValue(raw any) attr.Value {
+	switch v := raw.(type) {
+	case string:
+		return types.StringValue(v)
+	case bool:
+		return types.BoolValue(v)
+	case float64:
+		return types.NumberValue(big.NewFloat(v))
+	case []any:
+		elems := make([]attr.Value, 0, len(v))
+		elemTypes := make([]attr.Type, 0, len(v))
+		for _, e := range v {
+			ev := anyToValue(e)
+			elems = append(elems, ev)
+			elemTypes = append(elemTypes, ev.Type(context.Background()))
+		}
+		return types.TupleValueMust(elemTypes, elems)
+	case map[string]any:
+		attrs := make(map[string]attr.Value, len(v))
+		attrTypes := make(map[string]attr.Type, len(v))
+		for k, e := range v {
+			ev := anyToValue(e)
+			attrs[k] = ev
+			attrTypes[k] = ev.Type(context.Background())
+		}
+		return types.ObjectValueMust(attrTypes, attrs)
+	}
+	return types.StringNull()
+}
+
+// jsonEqual returns whether two values are equal once encoded in JSON,
+// e.g. an int64 and a float64 of the same value.
+func jsonEqual(a, b any) bool {
+	var na, nb any
+	ba, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	if err := json.Unmarshal(ba, &na); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(bb, &nb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+var (
+	// ChallengeResourceAttributes is exported for ease of extending
+	// CTFd through a plugin. Under normal circumpstances, you should
+	// not use it.
+	ChallengeResourceAttributes = utils.BlindMerge(ChallengeStandardResourceAttributes, map[string]schema.Attribute{
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Type of the challenge, as registered by the CTFd plugin that brings it (e.g. `multiple_choice` or `manual`). Changing it requires full replacement.",
+			Required:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"extra": schema.DynamicAttribute{
+			MarkdownDescription: "Attributes specific to the challenge type, passed as-is to CTFd. It must be an object (or a map) indexed by the CTFd attribute name, and cannot contain the ones managed by other attributes. Only those values are read back from CTFd, so they are not imported.",
+			Optional:            true,
+		},
+	})
+)
@@ -0,0 +1,87 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Challenge_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "Oh no ! I did not see my connection was no encrypted !"
+	value       = 500
+	state       = "hidden"
+	type        = "dynamic"
+
+	extra = {
+		initial  = 500
+		decay    = 17
+		minimum  = 50
+		function = "linear"
+	}
+
+	topics = [
+		"Network"
+	]
+	tags = [
+		"network"
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_challenge.http", "id"),
+					resource.TestCheckResourceAttr("ctfd_challenge.http", "type", "dynamic"),
+					resource.TestCheckResourceAttr("ctfd_challenge.http", "extra.decay", "17"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ctfd_challenge.http",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"extra"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge" "http" {
+	name        = "HTTP Authentication"
+	category    = "network"
+	description = "Oh no ! I did not see my connection was no encrypted !"
+	value       = 500
+	state       = "visible"
+	type        = "dynamic"
+
+	extra = {
+		initial  = 500
+		decay    = 20
+		minimum  = 100
+		function = "logarithmic"
+	}
+
+	topics = [
+		"Network"
+	]
+	tags = [
+		"network",
+		"http"
+	]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_challenge.http", "extra.function", "logarithmic"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*challengeSolutionResource)(nil)
+	_ resource.ResourceWithConfigure   = (*challengeSolutionResource)(nil)
+	_ resource.ResourceWithImportState = (*challengeSolutionResource)(nil)
+)
+
+var (
+	SolutionHidden  = types.StringValue("hidden")
+	SolutionVisible = types.StringValue("visible")
+	SolutionSolved  = types.StringValue("solved")
+)
+
+func NewChallengeSolutionResource() resource.Resource {
+	return &challengeSolutionResource{}
+}
+
+type challengeSolutionResource struct {
+	client   *api.Client
+	version  *ctfdVersion
+	readOnly bool
+}
+
+type challengeSolutionResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ChallengeID types.String `tfsdk:"challenge_id"`
+	Content     types.String `tfsdk:"content"`
+	State       types.String `tfsdk:"state"`
+}
+
+func (r *challengeSolutionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_challenge_solution"
+}
+
+func (r *challengeSolutionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The solution of a challenge, i.e. its official write-up. Requires CTFd 3.8 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the solution, used internally to handle the CTFd corresponding object.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"challenge_id": schema.StringAttribute{
+				MarkdownDescription: "Challenge of the solution.",
+				Required:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the solution, in markdown. Consider using multiline contents for better style.",
+				Required:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Visibility of the solution, either hidden, visible (to everyone) or solved (visible once the challenge is solved).",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(SolutionHidden.ValueString()),
+				Validators: []validator.String{
+					validators.NewStringEnumValidator([]basetypes.StringValue{
+						SolutionHidden,
+						SolutionVisible,
+						SolutionSolved,
+					}),
+				},
+			},
+		},
+	}
+}
+
+func (r *challengeSolutionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.version = data.Version
+	r.readOnly = data.ReadOnly
+}
+
+// The following types complete github.com/ctfer-io/go-ctfd/api with
+// the solutions, introduced in CTFd 3.8.
+
+type solution struct {
+	ID          int    `json:"id"`
+	ChallengeID int    `json:"challenge_id"`
+	Content     string `json:"content"`
+	State       string `json:"state"`
+}
+
+type postSolutionsParams struct {
+	ChallengeID int    `json:"challenge_id"`
+	Content     string `json:"content"`
+	State       string `json:"state"`
+}
+
+type patchSolutionParams struct {
+	ChallengeID int    `json:"challenge_id"`
+	Content     string `json:"content"`
+	State       string `json:"state"`
+}
+
+func (r *challengeSolutionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data challengeSolutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	requireVersion(&resp.Diagnostics, r.version, "Solutions", 3, 8)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create solution
+	res := &solution{}
+	if err := apiPost(ctx, r.client, "/solutions", &postSolutionsParams{
+		ChallengeID: utils.Atoi(data.ChallengeID.ValueString()),
+		Content:     data.Content.ValueString(),
+		State:       data.State.ValueString(),
+	}, res); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create solution, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a solution")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeSolutionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data challengeSolutionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve solution
+	res := &solution{}
+	if err := apiGet(ctx, r.client, "/solutions/"+data.ID.ValueString(), res); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read solution %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Upsert values
+	data.ChallengeID = types.StringValue(strconv.Itoa(res.ChallengeID))
+	data.Content = types.StringValue(res.Content)
+	data.State = types.StringValue(res.State)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeSolutionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data challengeSolutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update solution
+	if err := apiPatch(ctx, r.client, "/solutions/"+data.ID.ValueString(), &patchSolutionParams{
+		ChallengeID: utils.Atoi(data.ChallengeID.ValueString()),
+		Content:     data.Content.ValueString(),
+		State:       data.State.ValueString(),
+	}, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update solution %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *challengeSolutionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data challengeSolutionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := apiDelete(ctx, r.client, "/solutions/"+data.ID.ValueString()); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete solution %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *challengeSolutionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
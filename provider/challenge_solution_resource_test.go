@@ -0,0 +1,90 @@
+package provider_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_ChallengeSolution_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckSolutions(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_standard" "example" {
+	name        = "Example challenge"
+	category    = "test"
+	description = "Example challenge description..."
+	value       = 500
+}
+
+resource "ctfd_challenge_solution" "example" {
+	challenge_id = ctfd_challenge_standard.example.id
+	content      = "Read the description."
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_challenge_solution.example", "id"),
+					resource.TestCheckResourceAttr("ctfd_challenge_solution.example", "state", "hidden"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfd_challenge_solution.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_standard" "example" {
+	name        = "Example challenge"
+	category    = "test"
+	description = "Example challenge description..."
+	value       = 500
+}
+
+resource "ctfd_challenge_solution" "example" {
+	challenge_id = ctfd_challenge_standard.example.id
+	content      = <<-EOT
+		# Solution
+
+		Read the description, the flag is in it.
+	EOT
+	state = "solved"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_challenge_solution.example", "state", "solved"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccPreCheckSolutions skips the test if the CTFd instance does not
+// support solutions, as they were introduced in CTFd 3.8.
+func testAccPreCheckSolutions(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, os.Getenv("CTFD_URL")+"/api/v1/solutions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Token "+os.Getenv("CTFD_API_KEY"))
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		t.Skip("CTFd does not support solutions (requires 3.8 or later)")
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/ctfer-io/go-ctfd/api"
@@ -14,8 +15,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -25,9 +26,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*challengeStandardResource)(nil)
-	_ resource.ResourceWithConfigure   = (*challengeStandardResource)(nil)
-	_ resource.ResourceWithImportState = (*challengeStandardResource)(nil)
+	_ resource.Resource                 = (*challengeStandardResource)(nil)
+	_ resource.ResourceWithConfigure    = (*challengeStandardResource)(nil)
+	_ resource.ResourceWithImportState  = (*challengeStandardResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*challengeStandardResource)(nil)
 )
 
 func NewChallengeStandardResource() resource.Resource {
@@ -35,7 +37,8 @@ func NewChallengeStandardResource() resource.Resource {
 }
 
 type challengeStandardResource struct {
-	client *api.Client
+	client   *api.Client
+	readOnly bool
 }
 
 // ChallengeStandardResourceModel is exported for ease of extending
@@ -64,7 +67,10 @@ func (r *challengeStandardResource) Metadata(ctx context.Context, req resource.M
 func (r *challengeStandardResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "CTFd is built around the Challenge resource, which contains all the attributes to define a part of the Capture The Flag event.\n\nIt is the first historic implementation of its kind, with basic functionalities.",
-		Attributes:          ChallengeStandardResourceAttributes,
+		// Version 1 turned tags, topics and requirements.prerequisites from
+		// lists into sets.
+		Version:    1,
+		Attributes: ChallengeStandardResourceAttributes,
 	}
 }
 
@@ -74,19 +80,25 @@ func (r *challengeStandardResource) Configure(ctx context.Context, req resource.
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
 }
 
 func (r *challengeStandardResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data ChallengeStandardResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -94,18 +106,6 @@ func (r *challengeStandardResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	// Create Challenge
-	reqs := (*api.Requirements)(nil)
-	if data.Requirements != nil {
-		preqs := make([]int, 0, len(data.Requirements.Prerequisites))
-		for _, preq := range data.Requirements.Prerequisites {
-			id, _ := strconv.Atoi(preq.ValueString())
-			preqs = append(preqs, id)
-		}
-		reqs = &api.Requirements{
-			Anonymize:     GetAnon(data.Requirements.Behavior),
-			Prerequisites: preqs,
-		}
-	}
 	res, err := r.client.PostChallenges(&api.PostChallengesParams{
 		Name:           data.Name.ValueString(),
 		Category:       data.Category.ValueString(),
@@ -117,7 +117,7 @@ func (r *challengeStandardResource) Create(ctx context.Context, req resource.Cre
 		State:          data.State.ValueString(),
 		Type:           "standard",
 		NextID:         utils.ToInt(data.Next),
-		Requirements:   reqs,
+		Requirements:   data.GetRequirements(),
 	}, api.WithContext(ctx))
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -132,48 +132,13 @@ func (r *challengeStandardResource) Create(ctx context.Context, req resource.Cre
 	// Save computed attributes in state
 	data.ID = types.StringValue(strconv.Itoa(res.ID))
 
-	// Create tags
-	challTags := make([]types.String, 0, len(data.Tags))
-	for _, tag := range data.Tags {
-		_, err := r.client.PostTags(&api.PostTagsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Value:     tag.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create tags, got error: %s", err),
-			)
-			return
-		}
-		challTags = append(challTags, tag)
-	}
-	if data.Tags != nil {
-		data.Tags = challTags
-	}
-
-	// Create topics
-	challTopics := make([]types.String, 0, len(data.Topics))
-	for _, topic := range data.Topics {
-		_, err := r.client.PostTopics(&api.PostTopicsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Type:      "challenge",
-			Value:     topic.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create topic, got error: %s", err),
-			)
-			return
-		}
-		challTopics = append(challTopics, topic)
-	}
-	if data.Topics != nil {
-		data.Topics = challTopics
-	}
+	// Create subresources
+	resp.Diagnostics.Append(data.CreateSubresources(ctx, r.client)...)
 
 	if resp.Diagnostics.HasError() {
+		rollbackCreate(ctx, resp, "Challenge", data.ID.ValueString(), func(ctx context.Context) error {
+			return r.client.DeleteChallenge(res.ID, api.WithContext(ctx))
+		})
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -186,36 +151,36 @@ func (r *challengeStandardResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	data.Read(ctx, r.client, resp.Diagnostics)
-
+	resp.Diagnostics.Append(data.Read(ctx, r.client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if data.ID.IsNull() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *challengeStandardResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data ChallengeStandardResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	var dataState ChallengeStandardResourceModel
-	req.State.Get(ctx, &dataState)
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Patch direct attributes
-	reqs := (*api.Requirements)(nil)
-	if data.Requirements != nil {
-		preqs := make([]int, 0, len(data.Requirements.Prerequisites))
-		for _, preq := range data.Requirements.Prerequisites {
-			id, _ := strconv.Atoi(preq.ValueString())
-			preqs = append(preqs, id)
-		}
-		reqs = &api.Requirements{
-			Anonymize:     GetAnon(data.Requirements.Behavior),
-			Prerequisites: preqs,
-		}
-	}
 	_, err := r.client.PatchChallenge(utils.Atoi(data.ID.ValueString()), &api.PatchChallengeParams{
 		Name:           data.Name.ValueString(),
 		Category:       data.Category.ValueString(),
@@ -226,7 +191,7 @@ func (r *challengeStandardResource) Update(ctx context.Context, req resource.Upd
 		Value:          utils.ToInt(data.Value),
 		State:          data.State.ValueString(),
 		NextID:         utils.ToInt(data.Next),
-		Requirements:   reqs,
+		Requirements:   data.GetRequirements(),
 	}, api.WithContext(ctx))
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -236,83 +201,8 @@ func (r *challengeStandardResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	// Update its tags (drop them all, create new ones)
-	challTags, err := r.client.GetChallengeTags(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to get all tags of challenge %s, got error: %s", data.ID.ValueString(), err),
-		)
-		return
-	}
-	for _, tag := range challTags {
-		if err := r.client.DeleteTag(strconv.Itoa(tag.ID), api.WithContext(ctx)); err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to delete tag %d of challenge %s, got error: %s", tag.ID, data.ID.ValueString(), err),
-			)
-			return
-		}
-	}
-	tags := make([]types.String, 0, len(data.Tags))
-	for _, tag := range data.Tags {
-		_, err := r.client.PostTags(&api.PostTagsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Value:     tag.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create tag of challenge %s, got error: %s", data.ID.ValueString(), err),
-			)
-			return
-		}
-		tags = append(tags, tag)
-	}
-	if data.Tags != nil {
-		data.Tags = tags
-	}
-
-	// Update its topics (drop them all, create new ones)
-	challTopics, err := r.client.GetChallengeTopics(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx))
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to get all topics of challenge %s, got error: %s", data.ID.ValueString(), err),
-		)
-		return
-	}
-	for _, topic := range challTopics {
-		if err := r.client.DeleteTopic(&api.DeleteTopicArgs{
-			ID:   strconv.Itoa(topic.ID),
-			Type: "challenge",
-		}, api.WithContext(ctx)); err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to delete topic %d of challenge %s, got error: %s", topic.ID, data.ID.ValueString(), err),
-			)
-			return
-		}
-	}
-	topics := make([]types.String, 0, len(data.Topics))
-	for _, topic := range data.Topics {
-		_, err := r.client.PostTopics(&api.PostTopicsParams{
-			Challenge: utils.Atoi(data.ID.ValueString()),
-			Type:      "challenge",
-			Value:     topic.ValueString(),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to create topic of challenge %s, got error: %s", data.ID.ValueString(), err),
-			)
-			return
-		}
-		topics = append(topics, topic)
-	}
-	if data.Topics != nil {
-		data.Topics = topics
-	}
+	// Update subresources
+	resp.Diagnostics.Append(data.UpdateSubresources(ctx, r.client)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -321,6 +211,11 @@ func (r *challengeStandardResource) Update(ctx context.Context, req resource.Upd
 }
 
 func (r *challengeStandardResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data ChallengeStandardResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -328,6 +223,10 @@ func (r *challengeStandardResource) Delete(ctx context.Context, req resource.Del
 	}
 
 	if err := r.client.DeleteChallenge(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete challenge, got error: %s", err))
 		return
 	}
@@ -341,16 +240,34 @@ func (r *challengeStandardResource) ImportState(ctx context.Context, req resourc
 	// Automatically call r.Read
 }
 
+func (r *challengeStandardResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	resp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, resp)
+	return setsUpgraders(resp.Schema)
+}
+
 //
 // Starting from this are helper or types-specific code related to the ctfd_challenge_standard resource
 //
 
-func (chall *ChallengeStandardResourceModel) Read(ctx context.Context, client *api.Client, diags diag.Diagnostics) {
+// Read refreshes the challenge from CTFd. Its ID is set to null if the
+// challenge does not exist anymore.
+func (chall *ChallengeStandardResourceModel) Read(ctx context.Context, client *api.Client) (diags diag.Diagnostics) {
 	res, err := client.GetChallenge(utils.Atoi(chall.ID.ValueString()), api.WithContext(ctx))
 	if err != nil {
+		if isNotFound(err) {
+			chall.ID = types.StringNull()
+			return
+		}
 		diags.AddError("Client Error", fmt.Sprintf("Unable to read challenge %s, got error: %s", chall.ID.ValueString(), err))
 		return
 	}
+	return chall.read(ctx, client, res)
+}
+
+// read refreshes the challenge out of its CTFd representation res, then
+// reads its subresources.
+func (chall *ChallengeStandardResourceModel) read(ctx context.Context, client *api.Client, res *api.Challenge) (diags diag.Diagnostics) {
 	chall.Name = types.StringValue(res.Name)
 	chall.Category = types.StringValue(res.Category)
 	chall.Description = types.StringValue(res.Description)
@@ -397,7 +314,10 @@ func (chall *ChallengeStandardResourceModel) Read(ctx context.Context, client *a
 	}
 	chall.Tags = make([]basetypes.StringValue, 0, len(resTags))
 	for _, tag := range resTags {
-		chall.Tags = append(chall.Tags, types.StringValue(tag.Value))
+		// CTFd accepts the same tag many times, but it is only kept once in the set
+		if !slices.Contains(chall.Tags, types.StringValue(tag.Value)) {
+			chall.Tags = append(chall.Tags, types.StringValue(tag.Value))
+		}
 	}
 
 	// => Topics
@@ -411,8 +331,12 @@ func (chall *ChallengeStandardResourceModel) Read(ctx context.Context, client *a
 	}
 	chall.Topics = make([]basetypes.StringValue, 0, len(resTopics))
 	for _, topic := range resTopics {
-		chall.Topics = append(chall.Topics, types.StringValue(topic.Value))
+		// CTFd accepts the same topic many times, but it is only kept once in the set
+		if !slices.Contains(chall.Topics, types.StringValue(topic.Value)) {
+			chall.Topics = append(chall.Topics, types.StringValue(topic.Value))
+		}
 	}
+	return
 }
 
 var (
@@ -491,26 +415,26 @@ var (
 						}),
 					},
 				},
-				"prerequisites": schema.ListAttribute{
+				"prerequisites": schema.SetAttribute{
 					MarkdownDescription: "List of the challenges ID.",
 					Optional:            true,
 					ElementType:         types.StringType,
 				},
 			},
 		},
-		"tags": schema.ListAttribute{
+		"tags": schema.SetAttribute{
 			MarkdownDescription: "List of challenge tags that will be displayed to the end-user. You could use them to give some quick insights of what a challenge involves.",
 			ElementType:         types.StringType,
 			Optional:            true,
 			Computed:            true,
-			Default:             listdefault.StaticValue(basetypes.NewListValueMust(types.StringType, []attr.Value{})),
+			Default:             setdefault.StaticValue(basetypes.NewSetValueMust(types.StringType, []attr.Value{})),
 		},
-		"topics": schema.ListAttribute{
+		"topics": schema.SetAttribute{
 			MarkdownDescription: "List of challenge topics that are displayed to the administrators for maintenance and planification.",
 			ElementType:         types.StringType,
 			Optional:            true,
 			Computed:            true,
-			Default:             listdefault.StaticValue(basetypes.NewListValueMust(types.StringType, []attr.Value{})),
+			Default:             setdefault.StaticValue(basetypes.NewSetValueMust(types.StringType, []attr.Value{})),
 		},
 	}
 )
@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*commentResource)(nil)
+	_ resource.ResourceWithConfigure      = (*commentResource)(nil)
+	_ resource.ResourceWithImportState    = (*commentResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*commentResource)(nil)
+)
+
+func NewCommentResource() resource.Resource {
+	return &commentResource{}
+}
+
+type commentResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+type commentResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	ChallengeID types.String `tfsdk:"challenge_id"`
+	UserID      types.String `tfsdk:"user_id"`
+	TeamID      types.String `tfsdk:"team_id"`
+	PageID      types.String `tfsdk:"page_id"`
+	Content     types.String `tfsdk:"content"`
+}
+
+func (r *commentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_comment"
+}
+
+func (r *commentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A comment only visible to the administrators, for instance to record the review of a challenge. It targets exactly one of a challenge, a user, a team or a page. CTFd does not permit updating a comment, so any change requires full replacement.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the comment, used internally to handle the CTFd corresponding object.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"challenge_id": commentTargetAttribute("Challenge the comment is about."),
+			"user_id":      commentTargetAttribute("User the comment is about."),
+			"team_id":      commentTargetAttribute("Team the comment is about."),
+			"page_id":      commentTargetAttribute("Page the comment is about."),
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the comment, in markdown.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func commentTargetAttribute(desc string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: desc + " Exactly one of `challenge_id`, `user_id`, `team_id` and `page_id` must be set.",
+		Optional:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+func (r *commentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data commentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(data.targets()) != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid comment target",
+			"A comment must target exactly one of challenge_id, user_id, team_id and page_id.",
+		)
+	}
+}
+
+func (r *commentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+// postCommentsParams completes api.PostCommentsParams with all
+// the targets a comment can have.
+type postCommentsParams struct {
+	Content     string `json:"content"`
+	Type        string `json:"type"`
+	ChallengeID *int   `json:"challenge_id,omitempty"`
+	UserID      *int   `json:"user_id,omitempty"`
+	TeamID      *int   `json:"team_id,omitempty"`
+	PageID      *int   `json:"page_id,omitempty"`
+}
+
+func (r *commentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data commentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create comment
+	params := &postCommentsParams{
+		Content: data.Content.ValueString(),
+	}
+	for typ, id := range data.targets() {
+		params.Type = typ
+		target := utils.Ptr(utils.Atoi(id.ValueString()))
+		switch typ {
+		case "challenge":
+			params.ChallengeID = target
+		case "user":
+			params.UserID = target
+		case "team":
+			params.TeamID = target
+		case "page":
+			params.PageID = target
+		}
+	}
+	res := &api.Comment{}
+	if err := apiPost(ctx, r.client, "/comments", params, res); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create comment, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a comment")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *commentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data commentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve comment, CTFd only exposes them by target
+	query := url.Values{}
+	for typ, id := range data.targets() {
+		query.Set(typ+"_id", id.ValueString())
+	}
+	res, err := getComment(ctx, r.client, query, data.ID.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read comments, got error: %s", err),
+		)
+		return
+	}
+	if res == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Upsert values
+	data.Content = utils.ToTFString(res.Content)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *commentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data commentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddError("Provider Error", "CTFd does not permit update of comment-related information thus this provider cannot do so. This operation should not have been possible.")
+}
+
+func (r *commentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data commentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteComment(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete comment %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *commentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// CTFd only exposes the comments by target, so it must be part of the ID
+	pts := strings.Split(req.ID, "/")
+	if len(pts) != 3 {
+		resp.Diagnostics.AddError(
+			"Import Error",
+			fmt.Sprintf("Invalid comment import ID %s, expected <type>/<target_id>/<comment_id> (e.g. challenge/1/2).", req.ID),
+		)
+		return
+	}
+	attr := ""
+	switch pts[0] {
+	case "challenge", "user", "team", "page":
+		attr = pts[0] + "_id"
+	default:
+		resp.Diagnostics.AddError(
+			"Import Error",
+			fmt.Sprintf("Invalid comment type %s, expected one of challenge, user, team or page.", pts[0]),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(attr), pts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), pts[2])...)
+
+	// Automatically call r.Read
+}
+
+// targets returns the defined targets of the comment, indexed by their type.
+func (data *commentResourceModel) targets() map[string]types.String {
+	targets := map[string]types.String{}
+	for typ, id := range map[string]types.String{
+		"challenge": data.ChallengeID,
+		"user":      data.UserID,
+		"team":      data.TeamID,
+		"page":      data.PageID,
+	} {
+		if !id.IsNull() {
+			targets[typ] = id
+		}
+	}
+	return targets
+}
+
+// getComment looks for the comment id through all the pages of the
+// comments matching query, and returns nil if it is not found.
+func getComment(ctx context.Context, client *api.Client, query url.Values, id string) (*api.Comment, error) {
+	page := utils.Ptr(1)
+	for page != nil {
+		query.Set("page", strconv.Itoa(*page))
+		comments := []*api.Comment{}
+		next, err := apiGetPage(ctx, client, "/comments?"+query.Encode(), &comments)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range comments {
+			if strconv.Itoa(c.ID) == id {
+				return c, nil
+			}
+		}
+		page = next
+	}
+	return nil, nil
+}
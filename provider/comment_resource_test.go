@@ -0,0 +1,108 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAcc_Comment_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_standard" "example" {
+	name        = "Example challenge"
+	category    = "test"
+	description = "Example challenge description..."
+	value       = 500
+}
+
+resource "ctfd_comment" "review" {
+	challenge_id = ctfd_challenge_standard.example.id
+	content      = "Tested by CTFer."
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_comment.review", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "ctfd_comment.review",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["ctfd_comment.review"]
+					return fmt.Sprintf("challenge/%s/%s", rs.Primary.Attributes["challenge_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_challenge_standard" "example" {
+	name        = "Example challenge"
+	category    = "test"
+	description = "Example challenge description..."
+	value       = 500
+}
+
+resource "ctfd_comment" "review" {
+	challenge_id = ctfd_challenge_standard.example.id
+	content      = "Tested by CTFer, works as expected."
+}
+
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer"
+	email    = "ctfer-io-comment@protonmail.com"
+	password = "password"
+}
+
+resource "ctfd_comment" "user" {
+	user_id = ctfd_user.ctfer.id
+	content = "Author of the example challenge."
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_comment.review", "content", "Tested by CTFer, works as expected."),
+					resource.TestCheckResourceAttrSet("ctfd_comment.user", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAcc_Comment_InvalidTarget(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// No target
+			{
+				Config: providerConfig + `
+resource "ctfd_comment" "invalid" {
+	content = "About nothing."
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid comment target"),
+			},
+			// Multiple targets
+			{
+				Config: providerConfig + `
+resource "ctfd_comment" "invalid" {
+	user_id = "1"
+	team_id = "1"
+	content = "About too many things."
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid comment target"),
+			},
+		},
+	})
+}
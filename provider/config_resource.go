@@ -0,0 +1,551 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                   = (*configResource)(nil)
+	_ resource.ResourceWithConfigure      = (*configResource)(nil)
+	_ resource.ResourceWithImportState    = (*configResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*configResource)(nil)
+)
+
+// configID is the identifier of the ctfd_config singleton.
+const configID = "config"
+
+func NewConfigResource() resource.Resource {
+	return &configResource{}
+}
+
+type configResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+type configResourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Description            types.String `tfsdk:"description"`
+	Start                  types.String `tfsdk:"start"`
+	End                    types.String `tfsdk:"end"`
+	Freeze                 types.String `tfsdk:"freeze"`
+	UserMode               types.String `tfsdk:"user_mode"`
+	TeamSize               types.Int64  `tfsdk:"team_size"`
+	RegistrationVisibility types.String `tfsdk:"registration_visibility"`
+	ScoreVisibility        types.String `tfsdk:"score_visibility"`
+	AccountVisibility      types.String `tfsdk:"account_visibility"`
+	ChallengeVisibility    types.String `tfsdk:"challenge_visibility"`
+	Paused                 types.Bool   `tfsdk:"paused"`
+	Theme                  types.String `tfsdk:"theme"`
+	ThemeHeader            types.String `tfsdk:"theme_header"`
+	ThemeFooter            types.String `tfsdk:"theme_footer"`
+	ThemeSettings          types.String `tfsdk:"theme_settings"`
+	Extra                  types.Map    `tfsdk:"extra"`
+}
+
+func (r *configResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_config"
+}
+
+func (r *configResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The global configuration of the CTFd instance, i.e. the event settings. It is a singleton: define it only once.\n\nOnly the attributes you define are managed, the others are read from the CTFd instance. Destroying this resource does not reset the CTFd configuration, it only removes it from the Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the configuration, always `" + configID + "`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name":        configStringAttribute("Name of the event (`ctf_name`)."),
+			"description": configStringAttribute("Description of the event (`ctf_description`)."),
+			"start":       configStringAttribute("Start of the event as an RFC 3339 timestamp (e.g. `2026-10-16T08:00:00Z`)."),
+			"end":         configStringAttribute("End of the event as an RFC 3339 timestamp (e.g. `2026-10-17T20:00:00Z`)."),
+			"freeze":      configStringAttribute("Freeze of the scoreboard as an RFC 3339 timestamp, after which solves are not accounted for."),
+			"user_mode": configStringAttribute(
+				"Mode of the event, either users or teams. Be careful, changing it on a running instance requires all the users and teams to be reset.",
+				types.StringValue("users"),
+				types.StringValue("teams"),
+			),
+			"team_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of members in a team (`0` means unlimited).",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"registration_visibility": configStringAttribute(
+				"Who can register, either public, private or mlc.",
+				types.StringValue("public"),
+				types.StringValue("private"),
+				types.StringValue("mlc"),
+			),
+			"score_visibility": configStringAttribute(
+				"Who can see the scoreboard, either public, private, hidden or admins.",
+				types.StringValue("public"),
+				types.StringValue("private"),
+				types.StringValue("hidden"),
+				types.StringValue("admins"),
+			),
+			"account_visibility": configStringAttribute(
+				"Who can see the user and team accounts, either public, private or admins.",
+				types.StringValue("public"),
+				types.StringValue("private"),
+				types.StringValue("admins"),
+			),
+			"challenge_visibility": configStringAttribute(
+				"Who can see the challenges, either public, private or admins.",
+				types.StringValue("public"),
+				types.StringValue("private"),
+				types.StringValue("admins"),
+			),
+			"paused": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the event is paused, thus no more flags can be submitted.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"theme":          configStringAttribute("Theme of the CTFd instance (`ctf_theme`), e.g. `core`."),
+			"theme_header":   configStringAttribute("Custom HTML content injected in the header of every page."),
+			"theme_footer":   configStringAttribute("Custom HTML content injected in the footer of every page."),
+			"theme_settings": configStringAttribute("Settings of the theme, as a JSON string."),
+			"extra": schema.MapAttribute{
+				MarkdownDescription: "Other CTFd configuration keys to manage, with their values. Keys removed from this map are deleted from the CTFd configuration. It cannot contain the keys already covered by the other attributes.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func configStringAttribute(desc string, values ...types.String) schema.StringAttribute {
+	attr := schema.StringAttribute{
+		MarkdownDescription: desc,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	if len(values) != 0 {
+		attr.Validators = []validator.String{
+			validators.NewStringEnumValidator(values),
+		}
+	}
+	return attr
+}
+
+func (r *configResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data configResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fields := data.fields()
+	for _, f := range fields {
+		if !f.Timestamp {
+			continue
+		}
+		v := f.Value.(*types.String)
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, v.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.Attribute),
+				"Invalid timestamp",
+				fmt.Sprintf("Expected an RFC 3339 timestamp, got error: %s", err),
+			)
+		}
+	}
+
+	if data.Extra.IsNull() || data.Extra.IsUnknown() {
+		return
+	}
+	for k := range data.Extra.Elements() {
+		for _, f := range fields {
+			if f.Key == k {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("extra").AtMapKey(k),
+					"Managed configuration key",
+					fmt.Sprintf("The key %s is already managed by attribute %s, please use it instead.", k, f.Attribute),
+				)
+			}
+		}
+	}
+}
+
+func (r *configResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+func (r *configResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data configResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Patch the defined configuration values, the others are computed
+	params := map[string]any{}
+	for _, f := range data.fields() {
+		if v, ok := f.apiValue(); ok {
+			params[f.Key] = v
+		}
+	}
+	for k, v := range data.extra() {
+		params[k] = v
+	}
+	if err := apiPatch(ctx, r.client, "/configs", params, nil); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to patch configuration, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "patched the configuration")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(configID)
+	resp.Diagnostics.Append(data.Read(ctx, r.client)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *configResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data configResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.Read(ctx, r.client)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *configResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data configResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var dataState configResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &dataState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Patch only what changed, to avoid overwriting concurrent changes
+	params := map[string]any{}
+	fields, stateFields := data.fields(), dataState.fields()
+	for i, f := range fields {
+		if f.equal(stateFields[i]) {
+			continue
+		}
+		if v, ok := f.apiValue(); ok {
+			params[f.Key] = v
+		}
+	}
+	extra, stateExtra := data.extra(), dataState.extra()
+	for k, v := range extra {
+		if sv, ok := stateExtra[k]; !ok || sv != v {
+			params[k] = v
+		}
+	}
+	if len(params) != 0 {
+		if err := apiPatch(ctx, r.client, "/configs", params, nil); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to patch configuration, got error: %s", err),
+			)
+			return
+		}
+	}
+
+	// Delete the extra keys that are no longer managed
+	for k := range stateExtra {
+		if _, ok := extra[k]; ok {
+			continue
+		}
+		if err := r.client.DeleteConfigsByKey(k, api.WithContext(ctx)); err != nil {
+			if isNotFound(err) {
+				// Already deleted out of band
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to delete configuration key %s, got error: %s", k, err),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(data.Read(ctx, r.client)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *configResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data configResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The configuration of a CTFd instance cannot be deleted, and resetting it
+	// could break the event. It is only removed from the state.
+	tflog.Warn(ctx, "configuration removed from the state, it is left as is on the CTFd instance")
+}
+
+func (r *configResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// There is only one configuration, whatever the ID
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), configID)...)
+
+	// Automatically call r.Read
+}
+
+//
+// Starting from this are helper or types-specific code related to the ctfd_config resource
+//
+
+// configField maps an attribute of the configResourceModel to its CTFd configuration key.
+type configField struct {
+	Attribute string
+	Key       string
+	// Value is either a *types.String, a *types.Int64 or a *types.Bool.
+	Value any
+	// Timestamp is true if the *types.String is an RFC 3339 timestamp,
+	// stored as a UNIX timestamp by CTFd.
+	Timestamp bool
+}
+
+func (data *configResourceModel) fields() []configField {
+	return []configField{
+		{Attribute: "name", Key: "ctf_name", Value: &data.Name},
+		{Attribute: "description", Key: "ctf_description", Value: &data.Description},
+		{Attribute: "start", Key: "start", Value: &data.Start, Timestamp: true},
+		{Attribute: "end", Key: "end", Value: &data.End, Timestamp: true},
+		{Attribute: "freeze", Key: "freeze", Value: &data.Freeze, Timestamp: true},
+		{Attribute: "user_mode", Key: "user_mode", Value: &data.UserMode},
+		{Attribute: "team_size", Key: "team_size", Value: &data.TeamSize},
+		{Attribute: "registration_visibility", Key: "registration_visibility", Value: &data.RegistrationVisibility},
+		{Attribute: "score_visibility", Key: "score_visibility", Value: &data.ScoreVisibility},
+		{Attribute: "account_visibility", Key: "account_visibility", Value: &data.AccountVisibility},
+		{Attribute: "challenge_visibility", Key: "challenge_visibility", Value: &data.ChallengeVisibility},
+		{Attribute: "paused", Key: "paused", Value: &data.Paused},
+		{Attribute: "theme", Key: "ctf_theme", Value: &data.Theme},
+		{Attribute: "theme_header", Key: "theme_header", Value: &data.ThemeHeader},
+		{Attribute: "theme_footer", Key: "theme_footer", Value: &data.ThemeFooter},
+		{Attribute: "theme_settings", Key: "theme_settings", Value: &data.ThemeSettings},
+	}
+}
+
+func (data *configResourceModel) extra() map[string]string {
+	out := map[string]string{}
+	if data.Extra.IsNull() || data.Extra.IsUnknown() {
+		return out
+	}
+	for k, v := range data.Extra.Elements() {
+		out[k] = v.(types.String).ValueString()
+	}
+	return out
+}
+
+func (f configField) equal(other configField) bool {
+	switch v := f.Value.(type) {
+	case *types.String:
+		return v.Equal(*other.Value.(*types.String))
+	case *types.Int64:
+		return v.Equal(*other.Value.(*types.Int64))
+	case *types.Bool:
+		return v.Equal(*other.Value.(*types.Bool))
+	}
+	return false
+}
+
+// apiValue returns the value to send to CTFd, and false if there is
+// none i.e. the value is null or unknown.
+func (f configField) apiValue() (any, bool) {
+	switch v := f.Value.(type) {
+	case *types.String:
+		if v.IsNull() || v.IsUnknown() {
+			return nil, false
+		}
+		if f.Timestamp {
+			// Validated in ValidateConfig
+			t, _ := time.Parse(time.RFC3339, v.ValueString())
+			return t.Unix(), true
+		}
+		return v.ValueString(), true
+	case *types.Int64:
+		if v.IsNull() || v.IsUnknown() {
+			return nil, false
+		}
+		return v.ValueInt64(), true
+	case *types.Bool:
+		if v.IsNull() || v.IsUnknown() {
+			return nil, false
+		}
+		return v.ValueBool(), true
+	}
+	return nil, false
+}
+
+// setValue sets the CTFd configuration value (or nil if not defined) to the field.
+func (f configField) setValue(raw any) error {
+	str, ok := ctfdString(raw)
+	switch v := f.Value.(type) {
+	case *types.String:
+		switch {
+		case !ok:
+			*v = types.StringNull()
+		case f.Timestamp:
+			if str == "" {
+				*v = types.StringNull()
+				return nil
+			}
+			ts, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				return err
+			}
+			t := time.Unix(ts, 0).UTC()
+			// Keep the current representation if it is the same instant (e.g. other timezone)
+			if cur, err := time.Parse(time.RFC3339, v.ValueString()); err == nil && cur.Equal(t) {
+				return nil
+			}
+			*v = types.StringValue(t.Format(time.RFC3339))
+		default:
+			*v = types.StringValue(str)
+		}
+	case *types.Int64:
+		if !ok || str == "" {
+			*v = types.Int64Null()
+			return nil
+		}
+		i, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return err
+		}
+		*v = types.Int64Value(i)
+	case *types.Bool:
+		if !ok {
+			*v = types.BoolNull()
+			return nil
+		}
+		switch strings.ToLower(str) {
+		case "1", "true", "y", "yes":
+			*v = types.BoolValue(true)
+		default:
+			*v = types.BoolValue(false)
+		}
+	}
+	return nil
+}
+
+func (data *configResourceModel) Read(ctx context.Context, client *api.Client) (diags diag.Diagnostics) {
+	// The values are not typed by CTFd, so don't use *api.Client.GetConfigs
+	configs := []*struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}{}
+	if err := apiGet(ctx, client, "/configs", &configs); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read configuration, got error: %s", err),
+		)
+		return
+	}
+	values := make(map[string]any, len(configs))
+	for _, c := range configs {
+		values[c.Key] = c.Value
+	}
+
+	for _, f := range data.fields() {
+		if err := f.setValue(values[f.Key]); err != nil {
+			diags.AddAttributeError(
+				path.Root(f.Attribute),
+				"CTFd Error",
+				fmt.Sprintf("Unable to parse configuration key %s, got error: %s", f.Key, err),
+			)
+		}
+	}
+
+	// Only refresh the managed extra keys
+	if !data.Extra.IsNull() && !data.Extra.IsUnknown() {
+		extra := map[string]string{}
+		for k := range data.Extra.Elements() {
+			if str, ok := ctfdString(values[k]); ok {
+				extra[k] = str
+			}
+		}
+		var d diag.Diagnostics
+		data.Extra, d = types.MapValueFrom(ctx, types.StringType, extra)
+		diags.Append(d...)
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Config_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_config" "event" {
+	name        = "CTFer.io"
+	description = "A CTF managed by Terraform."
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_config.event", "id", "config"),
+					resource.TestCheckResourceAttr("ctfd_config.event", "name", "CTFer.io"),
+					// Verify computed values are read from the instance (CI runs in teams mode).
+					resource.TestCheckResourceAttr("ctfd_config.event", "user_mode", "teams"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfd_config.event",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_config" "event" {
+	name         = "CTFer.io"
+	description  = "A CTF managed by Terraform, and so is its configuration."
+	theme_footer = "<p>Powered by CTFer.io</p>"
+	freeze       = "2099-01-01T00:00:00Z"
+
+	extra = {
+		"tfp_test_key" = "value"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_config.event", "theme_footer", "<p>Powered by CTFer.io</p>"),
+					resource.TestCheckResourceAttr("ctfd_config.event", "freeze", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("ctfd_config.event", "extra.tfp_test_key", "value"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,4 @@
+package provider
+
+// ParseChoices exposes parseChoices to the provider_test package.
+var ParseChoices = parseChoices
@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*fieldResource)(nil)
+	_ resource.ResourceWithConfigure   = (*fieldResource)(nil)
+	_ resource.ResourceWithImportState = (*fieldResource)(nil)
+)
+
+var (
+	FieldText    = types.StringValue("text")
+	FieldBoolean = types.StringValue("boolean")
+)
+
+func NewFieldResource() resource.Resource {
+	return &fieldResource{}
+}
+
+type fieldResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+type fieldResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Description types.String `tfsdk:"description"`
+	Editable    types.Bool   `tfsdk:"editable"`
+	Required    types.Bool   `tfsdk:"required"`
+	Public      types.Bool   `tfsdk:"public"`
+	Scope       types.String `tfsdk:"scope"`
+}
+
+func (r *fieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_field"
+}
+
+func (r *fieldResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A custom field asked to the users or teams on registration, such as their school or student ID. Their values are then set through the `fields` attribute of the `ctfd_user` and `ctfd_team` resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the field, used internally to handle the CTFd corresponding object.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the field, as displayed in the registration form.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the field value, either text or boolean.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(FieldText.ValueString()),
+				Validators: []validator.String{
+					validators.NewStringEnumValidator([]basetypes.StringValue{
+						FieldText,
+						FieldBoolean,
+					}),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the field, displayed in the registration form.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"editable": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the end-users can edit the value after registration.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(false)),
+			},
+			"required": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the field is required on registration.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(false)),
+			},
+			"public": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the value is displayed on the public profile of the user or team.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(false)),
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Whether the field applies to users or teams, either user or team. Changing it requires full replacement.",
+				Required:            true,
+				Validators: []validator.String{
+					validators.NewStringEnumValidator([]basetypes.StringValue{
+						types.StringValue("user"),
+						types.StringValue("team"),
+					}),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *fieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+func (r *fieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data fieldResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create field
+	res, err := r.client.PostConfigFields(&api.PostConfigFieldsParams{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		FieldType:   data.Type.ValueString(),
+		Editable:    data.Editable.ValueBool(),
+		Public:      data.Public.ValueBool(),
+		Required:    data.Required.ValueBool(),
+		Type:        data.Scope.ValueString(),
+	}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create field, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a field")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *fieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data fieldResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve field
+	res, err := r.client.GetConfigsField(data.ID.ValueString(), api.WithContext(ctx))
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read field %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Upsert values
+	data.Name = types.StringPointerValue(res.Name)
+	data.Type = types.StringValue(fmt.Sprintf("%v", res.FieldType))
+	data.Description = types.StringValue("")
+	if res.Description != nil {
+		data.Description = types.StringValue(*res.Description)
+	}
+	data.Editable = types.BoolValue(res.Editable)
+	data.Required = types.BoolValue(res.Required)
+	data.Public = types.BoolValue(res.Public)
+	data.Scope = types.StringValue(res.Type)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *fieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data fieldResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update field
+	if _, err := r.client.PatchConfigsField(data.ID.ValueString(), &api.PatchConfigsFieldParams{
+		ID:          utils.Atoi(data.ID.ValueString()),
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		FieldType:   data.Type.ValueString(),
+		Type:        data.Scope.ValueString(),
+		Editable:    data.Editable.ValueBool(),
+		Public:      data.Public.ValueBool(),
+		Required:    data.Required.ValueBool(),
+	}, api.WithContext(ctx)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update field %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *fieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data fieldResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteConfigsField(data.ID.ValueString(), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete field %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *fieldResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
+
+//
+// Starting from this are helper or types-specific code related to the fields values of users and teams
+//
+
+// fieldEntry is the value of a custom field for a user or a team.
+// It replaces github.com/ctfer-io/go-ctfd/api.Field as CTFd stores
+// the boolean values as such.
+type fieldEntry struct {
+	FieldID int `json:"field_id"`
+	Value   any `json:"value"`
+}
+
+// fieldEntries returns the CTFd entries to send for the fields values,
+// or nil if they are not managed. The values that are no longer defined
+// are emptied.
+func fieldEntries(ctx context.Context, client *api.Client, fields, prev types.Map) ([]fieldEntry, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	if fields.IsNull() || fields.IsUnknown() {
+		return nil, diags
+	}
+	values := map[string]string{}
+	diags.Append(fields.ElementsAs(ctx, &values, false)...)
+	prevValues := map[string]string{}
+	if !prev.IsNull() && !prev.IsUnknown() {
+		diags.Append(prev.ElementsAs(ctx, &prevValues, false)...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// Boolean values must be sent as such
+	defs := []*api.ConfigField{}
+	if err := apiGet(ctx, client, "/configs/fields", &defs); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read fields, got error: %s", err),
+		)
+		return nil, diags
+	}
+	fieldTypes := make(map[string]string, len(defs))
+	for _, def := range defs {
+		fieldTypes[strconv.Itoa(def.ID)] = fmt.Sprintf("%v", def.FieldType)
+	}
+
+	entries := make([]fieldEntry, 0, len(values)+len(prevValues))
+	for id, v := range values {
+		ft, ok := fieldTypes[id]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("fields").AtMapKey(id),
+				"Unknown field",
+				fmt.Sprintf("Field %s does not exist.", id),
+			)
+			continue
+		}
+		entry := fieldEntry{FieldID: utils.Atoi(id), Value: v}
+		if ft == FieldBoolean.ValueString() {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("fields").AtMapKey(id),
+					"Invalid boolean field value",
+					fmt.Sprintf("Field %s is a boolean, got error: %s", id, err),
+				)
+				continue
+			}
+			entry.Value = b
+		}
+		entries = append(entries, entry)
+	}
+	for id := range prevValues {
+		if _, ok := values[id]; ok {
+			continue
+		}
+		ft, ok := fieldTypes[id]
+		if !ok {
+			// The field has been deleted in the meantime, so is its value
+			continue
+		}
+		entry := fieldEntry{FieldID: utils.Atoi(id), Value: ""}
+		if ft == FieldBoolean.ValueString() {
+			entry.Value = false
+		}
+		entries = append(entries, entry)
+	}
+	return entries, diags
+}
+
+// fieldValues returns the fields values of a user or a team from its CTFd entries.
+// If fields were already known, only those are refreshed, else all non-empty
+// values are returned (e.g. on import).
+func fieldValues(ctx context.Context, entries []fieldEntry, current types.Map) (types.Map, diag.Diagnostics) {
+	values := map[string]string{}
+	for _, entry := range entries {
+		id := strconv.Itoa(entry.FieldID)
+		str, ok := ctfdString(entry.Value)
+		if !ok {
+			continue
+		}
+		if current.IsNull() || current.IsUnknown() {
+			if str == "" {
+				continue
+			}
+		} else if _, ok := current.Elements()[id]; !ok {
+			continue
+		}
+		values[id] = str
+	}
+	return types.MapValueFrom(ctx, types.StringType, values)
+}
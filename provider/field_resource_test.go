@@ -0,0 +1,88 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Field_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_field" "school" {
+	name  = "School"
+	scope = "user"
+}
+
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer"
+	email    = "ctfer-io-field@protonmail.com"
+	password = "password"
+
+	fields = {
+		(ctfd_field.school.id) = "ENSIMAG"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_field.school", "id"),
+					resource.TestCheckResourceAttr("ctfd_field.school", "type", "text"),
+					resource.TestCheckResourceAttr("ctfd_field.school", "required", "false"),
+					resource.TestCheckResourceAttr("ctfd_user.ctfer", "fields.%", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfd_field.school",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:            "ctfd_user.ctfer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"}, // password can't be fetched from CTFd (security by design)
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_field" "school" {
+	name        = "School"
+	description = "The school you are studying at, if any."
+	editable    = true
+	public      = true
+	scope       = "user"
+}
+
+resource "ctfd_field" "student" {
+	name  = "Student"
+	type  = "boolean"
+	scope = "user"
+}
+
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer"
+	email    = "ctfer-io-field@protonmail.com"
+	password = "password"
+
+	fields = {
+		(ctfd_field.school.id)  = "Grenoble INP - Ensimag"
+		(ctfd_field.student.id) = "true"
+	}
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_field.school", "editable", "true"),
+					resource.TestCheckResourceAttr("ctfd_field.student", "type", "boolean"),
+					resource.TestCheckResourceAttr("ctfd_user.ctfer", "fields.%", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
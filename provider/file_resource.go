@@ -30,7 +30,8 @@ func NewFileResource() resource.Resource {
 }
 
 type fileResource struct {
-	client *api.Client
+	client   *api.Client
+	readOnly bool
 }
 
 type fileResourceModel struct {
@@ -100,19 +101,25 @@ func (r *fileResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
 }
 
 func (r *fileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data fileResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -171,6 +178,10 @@ func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	res, err := r.client.GetFile(data.ID.ValueString(), api.WithContext(ctx))
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"CTFd Error",
 			fmt.Sprintf("Unable to retrieve file %s, got error: %s", data.ID.ValueString(), err),
@@ -181,10 +192,12 @@ func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Name = types.StringValue(filepath.Base(res.Location))
 	data.Location = types.StringValue(res.Location)
 	data.SHA1Sum = types.StringValue(res.SHA1sum)
-	data.ChallengeID = lookForChallengeId(ctx, r.client, res.ID, resp.Diagnostics)
+	challID, diags := lookForChallengeId(ctx, r.client, res.ID)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	data.ChallengeID = challID
 
 	content, err := r.client.GetFileContent(&api.File{
 		Location: res.Location,
@@ -206,6 +219,11 @@ func (r *fileResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *fileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data fileResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -216,6 +234,11 @@ func (r *fileResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *fileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data fileResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -223,6 +246,10 @@ func (r *fileResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	if err := r.client.DeleteFile(data.ID.ValueString(), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete file %s, got error: %s", data.ID.ValueString(), err))
 		return
 	}
@@ -235,7 +262,8 @@ func (r *fileResource) ImportState(ctx context.Context, req resource.ImportState
 }
 
 // XXX this helper only exist because CTFd does not return the challenge id of a file if it exist...
-func lookForChallengeId(ctx context.Context, client *api.Client, fileID int, diags diag.Diagnostics) types.String {
+func lookForChallengeId(ctx context.Context, client *api.Client, fileID int) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	challs, err := client.GetChallenges(&api.GetChallengesParams{
 		View: utils.Ptr("admin"), // required, else CTFd only returns the "visible" challenges
 	}, api.WithContext(ctx))
@@ -244,7 +272,7 @@ func lookForChallengeId(ctx context.Context, client *api.Client, fileID int, dia
 			"CTFd Error",
 			fmt.Sprintf("Unable to query challenges, got error: %s", err),
 		)
-		return types.StringNull()
+		return types.StringNull(), diags
 	}
 
 	for _, chall := range challs {
@@ -254,13 +282,13 @@ func lookForChallengeId(ctx context.Context, client *api.Client, fileID int, dia
 				"CTFd Error",
 				fmt.Sprintf("Unable to query challenge %d files, got error: %s", chall.ID, err),
 			)
-			return types.StringNull()
+			return types.StringNull(), diags
 		}
 		for _, file := range files {
 			if file.ID == fileID {
-				return types.StringValue(strconv.Itoa(chall.ID))
+				return types.StringValue(strconv.Itoa(chall.ID)), diags
 			}
 		}
 	}
-	return types.StringNull()
+	return types.StringNull(), diags
 }
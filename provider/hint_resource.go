@@ -12,8 +12,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -21,9 +21,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*hintResource)(nil)
-	_ resource.ResourceWithConfigure   = (*hintResource)(nil)
-	_ resource.ResourceWithImportState = (*hintResource)(nil)
+	_ resource.Resource                 = (*hintResource)(nil)
+	_ resource.ResourceWithConfigure    = (*hintResource)(nil)
+	_ resource.ResourceWithImportState  = (*hintResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*hintResource)(nil)
 )
 
 func NewHintResource() resource.Resource {
@@ -31,7 +32,8 @@ func NewHintResource() resource.Resource {
 }
 
 type hintResource struct {
-	client *api.Client
+	client   *api.Client
+	readOnly bool
 }
 
 type hintResourceModel struct {
@@ -49,6 +51,8 @@ func (r *hintResource) Metadata(ctx context.Context, req resource.MetadataReques
 func (r *hintResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "A hint for a challenge to help players solve it.",
+		// Version 1 turned requirements from a list into a set.
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -71,12 +75,12 @@ func (r *hintResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 				Default:             int64default.StaticInt64(0),
 			},
-			"requirements": schema.ListAttribute{
+			"requirements": schema.SetAttribute{
 				MarkdownDescription: "List of the other hints it depends on.",
 				ElementType:         types.StringType,
 				Computed:            true,
 				Optional:            true,
-				Default:             listdefault.StaticValue(basetypes.NewListValueMust(types.StringType, []attr.Value{})),
+				Default:             setdefault.StaticValue(basetypes.NewSetValueMust(types.StringType, []attr.Value{})),
 			},
 		},
 	}
@@ -88,19 +92,25 @@ func (r *hintResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
 }
 
 func (r *hintResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data hintResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -150,6 +160,10 @@ func (r *hintResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Retrieve hint
 	h, err := r.client.GetHint(data.ID.ValueString(), api.WithContext(ctx))
 	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to update hint %s, got error: %s", data.ID.ValueString(), err),
@@ -165,6 +179,10 @@ func (r *hintResource) Read(ctx context.Context, req resource.ReadRequest, resp
 			break
 		}
 	}
+	if hint == nil && err == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if hint == nil {
 		resp.Diagnostics.AddError(
 			"CTFd Error",
@@ -190,6 +208,11 @@ func (r *hintResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *hintResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data hintResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -224,6 +247,11 @@ func (r *hintResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *hintResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data hintResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -231,6 +259,10 @@ func (r *hintResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	if err := r.client.DeleteHint(data.ID.ValueString(), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete hint %s, got error: %s", data.ID.ValueString(), err))
 		return
 	}
@@ -241,3 +273,9 @@ func (r *hintResource) ImportState(ctx context.Context, req resource.ImportState
 
 	// Automatically call r.Read
 }
+
+func (r *hintResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	resp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, resp)
+	return setsUpgraders(resp.Schema)
+}
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*notificationResource)(nil)
+	_ resource.ResourceWithConfigure   = (*notificationResource)(nil)
+	_ resource.ResourceWithImportState = (*notificationResource)(nil)
+)
+
+var (
+	NotificationToast      = types.StringValue("toast")
+	NotificationAlert      = types.StringValue("alert")
+	NotificationBackground = types.StringValue("background")
+)
+
+func NewNotificationResource() resource.Resource {
+	return &notificationResource{}
+}
+
+type notificationResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+type notificationResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Title   types.String `tfsdk:"title"`
+	Content types.String `tfsdk:"content"`
+	Type    types.String `tfsdk:"type"`
+	Sound   types.Bool   `tfsdk:"sound"`
+	UserID  types.String `tfsdk:"user_id"`
+	TeamID  types.String `tfsdk:"team_id"`
+}
+
+func (r *notificationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification"
+}
+
+func (r *notificationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A notification pushed to the end-users, for instance to announce a challenge fix or remind the flag format. CTFd does not permit updating a notification, so any change requires full replacement i.e. the notification is sent again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the notification, used internally to handle the CTFd corresponding object.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the notification.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the notification, in markdown.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "How the notification is displayed to the end-users, either toast, alert or background. It is not returned by CTFd thus is not imported.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(NotificationToast.ValueString()),
+				Validators: []validator.String{
+					validators.NewStringEnumValidator([]basetypes.StringValue{
+						NotificationToast,
+						NotificationAlert,
+						NotificationBackground,
+					}),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sound": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the notification plays a sound when displayed. It is not returned by CTFd thus is not imported.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(true)),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "User targeted by the notification. If not set, the notification is for everyone.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"team_id": schema.StringAttribute{
+				MarkdownDescription: "Team targeted by the notification. If not set, the notification is for everyone.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *notificationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+// postNotificationsParams completes api.PostNotificationsParams
+// with the optional user or team target.
+type postNotificationsParams struct {
+	api.PostNotificationsParams
+
+	UserID *int `json:"user_id,omitempty"`
+	TeamID *int `json:"team_id,omitempty"`
+}
+
+func (r *notificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data notificationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create notification
+	params := &postNotificationsParams{
+		PostNotificationsParams: api.PostNotificationsParams{
+			Title:   data.Title.ValueString(),
+			Content: data.Content.ValueString(),
+			Type:    data.Type.ValueString(),
+			Sound:   data.Sound.ValueBool(),
+		},
+	}
+	if !data.UserID.IsNull() {
+		params.UserID = utils.Ptr(utils.Atoi(data.UserID.ValueString()))
+	}
+	if !data.TeamID.IsNull() {
+		params.TeamID = utils.Ptr(utils.Atoi(data.TeamID.ValueString()))
+	}
+	res := &api.Notification{}
+	if err := apiPost(ctx, r.client, "/notifications", params, res); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create notification, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a notification")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data notificationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve notification
+	res, err := r.client.GetNotification(data.ID.ValueString(), api.WithContext(ctx))
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read notification %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Upsert values
+	data.Title = types.StringValue(res.Title)
+	data.Content = types.StringValue(res.Content)
+	data.UserID = types.StringNull()
+	if res.UserID != nil {
+		data.UserID = types.StringValue(strconv.Itoa(*res.UserID))
+	}
+	data.TeamID = types.StringNull()
+	if res.TeamID != nil {
+		data.TeamID = types.StringValue(strconv.Itoa(*res.TeamID))
+	}
+	// type and sound are not stored by CTFd, so keep the known values or
+	// fallback to the defaults (e.g. on import).
+	if data.Type.IsNull() {
+		data.Type = NotificationToast
+	}
+	if data.Sound.IsNull() {
+		data.Sound = types.BoolValue(true)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *notificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data notificationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddError("Provider Error", "CTFd does not permit update of notification-related information thus this provider cannot do so. This operation should not have been possible.")
+}
+
+func (r *notificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data notificationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteNotification(data.ID.ValueString(), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete notification %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *notificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// Automatically call r.Read
+}
@@ -0,0 +1,72 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Notification_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_notification" "flag_format" {
+	title   = "Flag format"
+	content = "Flags are in the format CTF{...}."
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_notification.flag_format", "id"),
+					resource.TestCheckResourceAttr("ctfd_notification.flag_format", "type", "toast"),
+					resource.TestCheckResourceAttr("ctfd_notification.flag_format", "sound", "true"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ctfd_notification.flag_format",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update (replace) and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_notification" "flag_format" {
+	title   = "Flag format"
+	content = "Reminder: flags are in the format CTF{...}."
+	type    = "alert"
+}
+
+resource "ctfd_user" "ctfer" {
+	name     = "CTFer"
+	email    = "ctfer-io-notification@protonmail.com"
+	password = "password"
+}
+
+resource "ctfd_team" "ctfer" {
+	name     = "CTFer notified"
+	email    = "ctfer-io-notification@protonmail.com"
+	password = "password"
+	members  = [ctfd_user.ctfer.id]
+	captain  = ctfd_user.ctfer.id
+}
+
+resource "ctfd_notification" "fixed" {
+	title   = "Challenge fixed"
+	content = "Your report was right, the challenge is now fixed. Thanks !"
+	team_id = ctfd_team.ctfer.id
+	sound   = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_notification.flag_format", "type", "alert"),
+					resource.TestCheckResourceAttrPair("ctfd_notification.fixed", "team_id", "ctfd_team.ctfer", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
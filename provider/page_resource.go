@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/validators"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = (*pageResource)(nil)
+	_ resource.ResourceWithConfigure   = (*pageResource)(nil)
+	_ resource.ResourceWithImportState = (*pageResource)(nil)
+)
+
+func NewPageResource() resource.Resource {
+	return &pageResource{}
+}
+
+type pageResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+type pageResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Title        types.String `tfsdk:"title"`
+	Route        types.String `tfsdk:"route"`
+	Content      types.String `tfsdk:"content"`
+	Format       types.String `tfsdk:"format"`
+	Draft        types.Bool   `tfsdk:"draft"`
+	Hidden       types.Bool   `tfsdk:"hidden"`
+	AuthRequired types.Bool   `tfsdk:"auth_required"`
+}
+
+func (r *pageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_page"
+}
+
+func (r *pageResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A custom page of the CTFd instance, such as the rules, a FAQ or the sponsors of the event.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the page, used internally to handle the CTFd corresponding object.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "Title of the page, displayed in the navigation bar.",
+				Required:            true,
+			},
+			"route": schema.StringAttribute{
+				MarkdownDescription: "Route of the page on the CTFd instance (e.g. `rules` will be served under `/rules`). It must be unique.",
+				Required:            true,
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Content of the page, consider using multiline contents for better style.",
+				Required:            true,
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "Format of the content, either markdown or html.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("markdown"),
+				Validators: []validator.String{
+					validators.NewStringEnumValidator([]basetypes.StringValue{
+						types.StringValue("markdown"),
+						types.StringValue("html"),
+					}),
+				},
+			},
+			"draft": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the page is a draft, thus not published.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(false)),
+			},
+			"hidden": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the page is hidden from the navigation bar. It remains reachable through its route.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(false)),
+			},
+			"auth_required": schema.BoolAttribute{
+				MarkdownDescription: "Is true if the end-user must be authenticated to access the page.",
+				Optional:            true,
+				Computed:            true,
+				Default:             defaults.Bool(booldefault.StaticBool(false)),
+			},
+		},
+	}
+}
+
+func (r *pageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+func (r *pageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data pageResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create page
+	res, err := r.client.PostPages(&api.PostPagesParams{
+		Title:        data.Title.ValueString(),
+		Route:        data.Route.ValueString(),
+		Content:      data.Content.ValueString(),
+		Format:       data.Format.ValueString(),
+		Draft:        data.Draft.ValueBool(),
+		Hidden:       data.Hidden.ValueBool(),
+		AuthRequired: data.AuthRequired.ValueBool(),
+	}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create page, got error: %s", err),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a page")
+
+	// Save computed attributes in state
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data pageResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve page
+	res, err := r.client.GetPage(data.ID.ValueString(), api.WithContext(ctx))
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to read page %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	// Upsert values
+	data.Title = types.StringValue(res.Title)
+	data.Route = types.StringValue(res.Route)
+	data.Content = utils.ToTFString(res.Content)
+	data.Format = types.StringValue(res.Format)
+	data.Draft = types.BoolValue(res.Draft)
+	data.Hidden = types.BoolValue(res.Hidden)
+	data.AuthRequired = types.BoolValue(res.AuthRequired)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data pageResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Update page
+	if _, err := r.client.PatchPage(data.ID.ValueString(), &api.PatchPageParams{
+		Title:        data.Title.ValueString(),
+		Route:        data.Route.ValueString(),
+		Content:      data.Content.ValueString(),
+		Format:       data.Format.ValueString(),
+		Draft:        data.Draft.ValueBool(),
+		Hidden:       data.Hidden.ValueBool(),
+		AuthRequired: data.AuthRequired.ValueBool(),
+	}, api.WithContext(ctx)); err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to update page %s, got error: %s", data.ID.ValueString(), err),
+		)
+		return
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *pageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
+	var data pageResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeletePage(data.ID.ValueString(), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete page %s, got error: %s", data.ID.ValueString(), err))
+		return
+	}
+}
+
+func (r *pageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import by ID
+	if _, err := strconv.Atoi(req.ID); err == nil {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	// Import by route, which is unique thus enough to identify the page
+	pages, err := r.client.GetPages(&api.GetPagesParams{
+		Route: &req.ID,
+	}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to query pages with route %s, got error: %s", req.ID, err),
+		)
+		return
+	}
+	for _, page := range pages {
+		if page.Route == req.ID {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(page.ID))...)
+
+			// Automatically call r.Read
+			return
+		}
+	}
+	resp.Diagnostics.AddError(
+		"Import Error",
+		fmt.Sprintf("No page found with route %s. Import expects either the page ID or its route.", req.ID),
+	)
+}
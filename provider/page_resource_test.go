@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAcc_Page_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_page" "rules" {
+	title   = "Rules"
+	route   = "rules"
+	content = "Be nice."
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// Verify dynamic values have any value set in the state.
+					resource.TestCheckResourceAttrSet("ctfd_page.rules", "id"),
+					resource.TestCheckResourceAttr("ctfd_page.rules", "format", "markdown"),
+					resource.TestCheckResourceAttr("ctfd_page.rules", "draft", "false"),
+					resource.TestCheckResourceAttr("ctfd_page.rules", "hidden", "false"),
+					resource.TestCheckResourceAttr("ctfd_page.rules", "auth_required", "false"),
+				),
+			},
+			// ImportState testing (by ID)
+			{
+				ResourceName:      "ctfd_page.rules",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// ImportState testing (by route)
+			{
+				ResourceName:      "ctfd_page.rules",
+				ImportState:       true,
+				ImportStateId:     "rules",
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ctfd_page" "rules" {
+	title   = "Rules"
+	route   = "rules"
+	content = <<-EOT
+		# Rules
+
+		Be nice, and do not attack the infrastructure.
+	EOT
+}
+
+resource "ctfd_page" "sponsors" {
+	title         = "Sponsors"
+	route         = "sponsors"
+	format        = "html"
+	content       = "<h1>Thanks to our sponsors !</h1>"
+	hidden        = true
+	auth_required = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ctfd_page.sponsors", "format", "html"),
+					resource.TestCheckResourceAttr("ctfd_page.sponsors", "hidden", "true"),
+					resource.TestCheckResourceAttr("ctfd_page.sponsors", "auth_required", "true"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
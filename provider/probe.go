@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// providerData is handed to the resources, data sources and ephemeral
+// resources once the provider is configured.
+type providerData struct {
+	Client *api.Client
+
+	// Version of CTFd, nil if it could not be detected (e.g. the user
+	// is not an admin).
+	Version *ctfdVersion
+
+	// ReadOnly is true if the provider must not create, update nor
+	// delete anything.
+	ReadOnly bool
+}
+
+// ctfdVersion is the version of a CTFd instance, as stored in its
+// ctf_version configuration.
+type ctfdVersion struct {
+	Major, Minor, Patch int
+}
+
+// parseVersion parses a CTFd version such as 3.7.4 or v3.8.0.
+func parseVersion(str string) (*ctfdVersion, error) {
+	parts := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(str), "v"), ".", 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid version %q", str)
+	}
+	nums := make([]int, 3)
+	for i, part := range parts {
+		// Drop pre-release and build metadata, e.g. 3.8.0-rc1
+		if j := strings.IndexAny(part, "-+"); j >= 0 && i == len(parts)-1 {
+			part = part[:j]
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", str, err)
+		}
+		nums[i] = n
+	}
+	return &ctfdVersion{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+func (v ctfdVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast returns whether the version is major.minor or later.
+func (v ctfdVersion) AtLeast(major, minor int) bool {
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// requireVersion adds an error to diags if the CTFd version is known to be
+// older than major.minor, the one that introduced feature.
+// Nothing is checked if the version is unknown, CTFd then tells by itself.
+func requireVersion(diags *diag.Diagnostics, version *ctfdVersion, feature string, major, minor int) {
+	if version == nil || version.AtLeast(major, minor) {
+		return
+	}
+	diags.AddError(
+		"Unsupported CTFd Version",
+		fmt.Sprintf("%s requires CTFd %d.%d or later, got %s.", feature, major, minor, version),
+	)
+}
+
+// probe checks the CTFd instance is reachable and accepts the credentials
+// of client, then detects whether they are admin ones and the CTFd version.
+// Credentials errors are reported on the authAttr attribute.
+func probe(ctx context.Context, client *api.Client, url, authAttr string) (*providerData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	me := &api.User{}
+	status, err := probeGet(ctx, client, "/users/me", me)
+	switch {
+	case status == 0:
+		diags.AddAttributeError(
+			path.Root("url"),
+			"Unreachable CTFd",
+			fmt.Sprintf("Unable to reach CTFd at %s, got error: %s", url, err),
+		)
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		diags.AddAttributeError(
+			path.Root(authAttr),
+			"Invalid CTFd credentials",
+			fmt.Sprintf("CTFd at %s rejected the credentials, they may be invalid or expired.", url),
+		)
+	case status == http.StatusNotFound:
+		diags.AddAttributeError(
+			path.Root("url"),
+			"CTFd API not found",
+			fmt.Sprintf("No CTFd API found at %s, the URL must be the CTFd base one.", url),
+		)
+	case err != nil:
+		diags.AddAttributeError(
+			path.Root("url"),
+			"Invalid CTFd response",
+			fmt.Sprintf("CTFd at %s responded with status %d, the URL may not be the one of a CTFd instance, got error: %s", url, status, err),
+		)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// The configurations are only readable by admins
+	data := &providerData{
+		Client: client,
+	}
+	config := struct {
+		Value *string `json:"value"`
+	}{}
+	status, err = probeGet(ctx, client, "/configs/ctf_version", &config)
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		diags.AddAttributeWarning(
+			path.Root(authAttr),
+			"Non-admin CTFd credentials",
+			fmt.Sprintf("The user %s is not an admin, so most resources and data sources will fail.", me.Name),
+		)
+		return data, diags
+	}
+	if err != nil || config.Value == nil {
+		tflog.Warn(ctx, "Unable to detect CTFd version", map[string]any{
+			"error": fmt.Sprint(err),
+		})
+		return data, diags
+	}
+	version, err := parseVersion(*config.Value)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to detect CTFd version", map[string]any{
+			"error": err.Error(),
+		})
+		return data, diags
+	}
+	data.Version = version
+
+	tflog.Info(ctx, "Detected CTFd version", map[string]any{
+		"ctfd_version": version.String(),
+	})
+	return data, diags
+}
+
+// probeGet issues a GET request to the CTFd API endpoint edp and decodes its
+// content into dst. It returns the response status, 0 if CTFd could not be
+// reached, and a redirection to the login page is considered as a 403.
+func probeGet(ctx context.Context, client *api.Client, edp string, dst any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/api/v1"+edp, nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		if isNotFound(err) {
+			return http.StatusNotFound, err
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if !strings.HasSuffix(res.Request.URL.Path, "/api/v1"+edp) {
+		return http.StatusForbidden, nil
+	}
+	resp := api.Response{
+		Data: dst,
+	}
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil {
+		return res.StatusCode, fmt.Errorf("CTFd responded with invalid JSON for content: %w", err)
+	}
+	if !resp.Success {
+		return res.StatusCode, fmt.Errorf("CTFd responded with no success, status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
@@ -2,11 +2,24 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	neturl "net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/transport"
 	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -15,7 +28,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-var _ provider.Provider = (*CTFdProvider)(nil)
+var (
+	_ provider.Provider                       = (*CTFdProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*CTFdProvider)(nil)
+)
 
 type CTFdProvider struct {
 	version string
@@ -34,6 +50,30 @@ type CTFdProviderModel struct {
 	Session types.String `tfsdk:"session"`
 	Nonce   types.String `tfsdk:"nonce"`
 	APIKey  types.String `tfsdk:"api_key"`
+
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+
+	ShortLivedToken types.Bool `tfsdk:"short_lived_token"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryMinWait types.String `tfsdk:"retry_min_wait"`
+	RetryMaxWait types.String `tfsdk:"retry_max_wait"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+
+	Headers types.Map `tfsdk:"headers"`
+
+	ReadOnly types.Bool `tfsdk:"read_only"`
 }
 
 func (p *CTFdProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -62,6 +102,12 @@ You must configure the provider with the proper credentials before you can use i
 !> **Warning:** Hard-coded credentials are not recommended in any Terraform
 configuration and risks secret leakage should this file ever be committed to a
 public version control system.
+
+## Debugging
+
+The CTFd API requests are logged in the ` + "`http`" + ` subsystem, whose level is set by the ` + "`TF_LOG_PROVIDER_CTFD_HTTP`" + ` environment variable.
+At ` + "`DEBUG`" + ` their method, path, status and latency are logged, at ` + "`TRACE`" + ` their headers and bodies too.
+Credentials, passwords, flag contents, token values, secret configurations (e.g. mail_password) and file contents are redacted.
 `,
 		Attributes: map[string]schema.Attribute{
 			"url": schema.StringAttribute{
@@ -79,10 +125,82 @@ public version control system.
 				Optional:            true,
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "User API key. Could use `CTFD_API_KEY` environment variable instead. Despite being the most convenient way to authenticate yourself, we do not recommend it as you will probably generate a long-live token without any rotation policy.",
+				MarkdownDescription: "User API key. Could use `CTFD_API_KEY` environment variable instead. Despite being the most convenient way to authenticate yourself, we do not recommend it as you will probably generate a long-live token without any rotation policy. Prefer the `short_lived_token` mode instead.",
+				Sensitive:           true,
+				Optional:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "User name to login with, comes with password. Could use `CTFD_USERNAME` environment variable instead. Only used if neither the API key nor the session and nonce are defined.",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "User password to login with, comes with username. Could use `CTFD_PASSWORD` environment variable instead.",
 				Sensitive:           true,
 				Optional:            true,
 			},
+			"short_lived_token": schema.BoolAttribute{
+				MarkdownDescription: "If true, once logged in with the username and password, the provider creates an API token that expires the next day, uses it for the run and deletes it when it shuts down. Terraform only leaves about 2 seconds to the provider to shut down, so if CTFd does not respond in time the token remains valid until it expires. Could use `CTFD_SHORT_LIVED_TOKEN` environment variable instead.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of retries of a CTFd API request that failed due to a transient error (e.g. a 502 or 503 from a reverse proxy, or a connection reset). Requests that are not idempotent (e.g. creations) are only retried if CTFd could not have processed them. Defaults to 3, set to 0 to disable retries. Could use `CTFD_MAX_RETRIES` environment variable instead.",
+				Optional:            true,
+			},
+			"retry_min_wait": schema.StringAttribute{
+				MarkdownDescription: "Wait before the first retry, doubled on every following one (e.g. `500ms`). Defaults to `1s`. Could use `CTFD_RETRY_MIN_WAIT` environment variable instead.",
+				Optional:            true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: "Maximum wait between two retries (e.g. `1m`). Defaults to `30s`. Could use `CTFD_RETRY_MAX_WAIT` environment variable instead.",
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum number of CTFd API requests per second, shared by all resources and data sources. Defaults to 0 i.e. unlimited. Could use `CTFD_REQUESTS_PER_SECOND` environment variable instead.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of concurrent CTFd API requests, shared by all resources and data sources. Useful for CTFd instances with few workers, without having to set Terraform `-parallelism`. Defaults to 0 i.e. unlimited. Could use `CTFD_MAX_CONCURRENT_REQUESTS` environment variable instead.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded certificates of the authorities to trust in addition to the system ones, e.g. an internal CA. Could use `CTFD_CA_CERT_PEM` environment variable instead.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing PEM-encoded certificates of the authorities to trust in addition to the system ones. Could use `CTFD_CA_CERT_FILE` environment variable instead.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate for mTLS, comes with client_key. Could use `CTFD_CLIENT_CERT` environment variable instead.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client private key for mTLS, comes with client_cert. Could use `CTFD_CLIENT_KEY` environment variable instead.",
+				Sensitive:           true,
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "If true, the CTFd certificate is not verified. This should only be used for testing purposes. Could use `CTFD_INSECURE_SKIP_VERIFY` environment variable instead.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy to reach CTFd through (e.g. `http://proxy.lan:3128`). If not set, the `HTTP_PROXY`, `HTTPS_PROXY` and `NO_PROXY` environment variables are used. Could use `CTFD_PROXY_URL` environment variable instead.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of every attempt of a CTFd API request (e.g. `30s`), including the read of the response. A timed out request is retried as any other transient error. Defaults to no timeout. Could use `CTFD_REQUEST_TIMEOUT` environment variable instead.",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Headers to add to every CTFd API request, e.g. the service tokens of an identity-aware proxy in front of CTFd. They don't override the ones set by the provider (e.g. `Authorization`). Could use `CTFD_HEADERS` environment variable instead, formatted as comma-separated `Name=value` pairs.",
+				ElementType:         types.StringType,
+				Sensitive:           true,
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "If true, every creation, update and deletion fails before reaching CTFd, while reads and data sources keep working, e.g. to report drifts from a CI without any possibility of mutating the CTFd instance. The `ctfd_token` ephemeral resource is not created (its value is null), and the `short_lived_token` mode keeps the login session rather than creating a token. Could use `CTFD_READ_ONLY` environment variable instead.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -124,6 +242,125 @@ func (p *CTFdProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 			"The provider cannot create the CTFd API client as there is an unknown API key value.",
 		)
 	}
+	if config.Username.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Unknown CTFd username.",
+			"The provider cannot create the CTFd API client as there is an unknown username value.",
+		)
+	}
+	if config.Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Unknown CTFd password.",
+			"The provider cannot create the CTFd API client as there is an unknown password value.",
+		)
+	}
+	if config.ShortLivedToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("short_lived_token"),
+			"Unknown CTFd short-lived token mode.",
+			"The provider cannot create the CTFd API client as there is an unknown short-lived token mode value.",
+		)
+	}
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown CTFd max retries.",
+			"The provider cannot create the CTFd API client as there is an unknown max retries value.",
+		)
+	}
+	if config.RetryMinWait.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Unknown CTFd retry min wait.",
+			"The provider cannot create the CTFd API client as there is an unknown retry min wait value.",
+		)
+	}
+	if config.RetryMaxWait.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_wait"),
+			"Unknown CTFd retry max wait.",
+			"The provider cannot create the CTFd API client as there is an unknown retry max wait value.",
+		)
+	}
+	if config.RequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Unknown CTFd requests per second.",
+			"The provider cannot create the CTFd API client as there is an unknown requests per second value.",
+		)
+	}
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown CTFd max concurrent requests.",
+			"The provider cannot create the CTFd API client as there is an unknown max concurrent requests value.",
+		)
+	}
+	if config.CACertPEM.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Unknown CTFd CA certificate PEM.",
+			"The provider cannot create the CTFd API client as there is an unknown CA certificate PEM value.",
+		)
+	}
+	if config.CACertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown CTFd CA certificates file.",
+			"The provider cannot create the CTFd API client as there is an unknown CA certificates file value.",
+		)
+	}
+	if config.ClientCert.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Unknown CTFd client certificate.",
+			"The provider cannot create the CTFd API client as there is an unknown client certificate value.",
+		)
+	}
+	if config.ClientKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_key"),
+			"Unknown CTFd client key.",
+			"The provider cannot create the CTFd API client as there is an unknown client key value.",
+		)
+	}
+	if config.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown CTFd insecure skip verify.",
+			"The provider cannot create the CTFd API client as there is an unknown insecure skip verify value.",
+		)
+	}
+	if config.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown CTFd proxy URL.",
+			"The provider cannot create the CTFd API client as there is an unknown proxy URL value.",
+		)
+	}
+	if config.RequestTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("request_timeout"),
+			"Unknown CTFd request timeout.",
+			"The provider cannot create the CTFd API client as there is an unknown request timeout value.",
+		)
+	}
+	if config.Headers.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("headers"),
+			"Unknown CTFd headers.",
+			"The provider cannot create the CTFd API client as there is an unknown headers value.",
+		)
+	}
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown CTFd read-only mode.",
+			"The provider cannot create the CTFd API client as there is an unknown read-only mode value.",
+		)
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -134,6 +371,9 @@ func (p *CTFdProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	session := os.Getenv("CTFD_SESSION")
 	nonce := os.Getenv("CTFD_NONCE")
 	apiKey := os.Getenv("CTFD_API_KEY")
+	username := os.Getenv("CTFD_USERNAME")
+	password := os.Getenv("CTFD_PASSWORD")
+	shortLivedToken, _ := strconv.ParseBool(os.Getenv("CTFD_SHORT_LIVED_TOKEN"))
 
 	if !config.URL.IsNull() {
 		url = config.URL.ValueString()
@@ -147,24 +387,124 @@ func (p *CTFdProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	if !config.APIKey.IsNull() {
 		apiKey = config.APIKey.ValueString()
 	}
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+	if !config.ShortLivedToken.IsNull() {
+		shortLivedToken = config.ShortLivedToken.ValueBool()
+	}
+	maxRetries := int64Value(&resp.Diagnostics, "max_retries", config.MaxRetries, "CTFD_MAX_RETRIES", 3)
+	retryMinWait := durationValue(&resp.Diagnostics, "retry_min_wait", config.RetryMinWait, "CTFD_RETRY_MIN_WAIT", time.Second)
+	retryMaxWait := durationValue(&resp.Diagnostics, "retry_max_wait", config.RetryMaxWait, "CTFD_RETRY_MAX_WAIT", 30*time.Second)
+	requestsPerSecond := float64Value(&resp.Diagnostics, "requests_per_second", config.RequestsPerSecond, "CTFD_REQUESTS_PER_SECOND", 0)
+	maxConcurrentRequests := int64Value(&resp.Diagnostics, "max_concurrent_requests", config.MaxConcurrentRequests, "CTFD_MAX_CONCURRENT_REQUESTS", 0)
+	caCertPEM := stringValue(config.CACertPEM, "CTFD_CA_CERT_PEM")
+	caCertFile := stringValue(config.CACertFile, "CTFD_CA_CERT_FILE")
+	clientCert := stringValue(config.ClientCert, "CTFD_CLIENT_CERT")
+	clientKey := stringValue(config.ClientKey, "CTFD_CLIENT_KEY")
+	insecureSkipVerify, _ := strconv.ParseBool(os.Getenv("CTFD_INSECURE_SKIP_VERIFY"))
+	if !config.InsecureSkipVerify.IsNull() {
+		insecureSkipVerify = config.InsecureSkipVerify.ValueBool()
+	}
+	proxyURL := stringValue(config.ProxyURL, "CTFD_PROXY_URL")
+	requestTimeout := durationValue(&resp.Diagnostics, "request_timeout", config.RequestTimeout, "CTFD_REQUEST_TIMEOUT", 0)
+	headers := headersValue(ctx, &resp.Diagnostics, config.Headers, "CTFD_HEADERS")
+	readOnly, _ := strconv.ParseBool(os.Getenv("CTFD_READ_ONLY"))
+	if !config.ReadOnly.IsNull() {
+		readOnly = config.ReadOnly.ValueBool()
+	}
+	if caCertFile != "" {
+		b, err := os.ReadFile(caCertFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Invalid CTFd CA certificates file",
+				fmt.Sprintf("Unable to read CA certificates file, got error: %s", err),
+			)
+		} else {
+			caCertPEM += "\n" + string(b)
+		}
+	}
+	if (clientCert == "") != (clientKey == "") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Invalid CTFd client certificate",
+			"Both the client certificate and key must be defined for mTLS.",
+		)
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid CTFd max retries",
+			fmt.Sprintf("The max retries must be positive, got %d.", maxRetries),
+		)
+	}
+	if requestsPerSecond < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Invalid CTFd requests per second",
+			fmt.Sprintf("The requests per second must be positive, got %g.", requestsPerSecond),
+		)
+	}
+	if maxConcurrentRequests < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid CTFd max concurrent requests",
+			fmt.Sprintf("The max concurrent requests must be positive, got %d.", maxConcurrentRequests),
+		)
+	}
+	if retryMinWait > retryMaxWait {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_min_wait"),
+			"Invalid CTFd retry min wait",
+			fmt.Sprintf("The retry min wait (%s) must not be greater than the retry max wait (%s).", retryMinWait, retryMaxWait),
+		)
+	}
 
 	// Check there is enough content
-	if apiKey == "" {
+	login := apiKey == "" && (session == "" || nonce == "") && (username != "" || password != "")
+	if apiKey == "" && !login {
 		if session == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("session"),
 				"Missing CTFd session",
-				"The provider cannot create the CTFd API client as there is a missing value for the CTFd API session, as the API key is not defined.",
+				"The provider cannot create the CTFd API client as there is a missing value for the CTFd API session, as neither the API key nor the username and password are defined.",
 			)
 		}
 		if nonce == "" {
 			resp.Diagnostics.AddAttributeError(
 				path.Root("nonce"),
 				"Missing CTFd nonce",
-				"The provider cannot create the CTFd API client as there is a missing value for the CTFd API nonce, as the API key is not defined.",
+				"The provider cannot create the CTFd API client as there is a missing value for the CTFd API nonce, as neither the API key nor the username and password are defined.",
 			)
 		}
 	}
+	if login {
+		if username == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Missing CTFd username",
+				"The provider cannot login to CTFd as there is a missing value for the username, while the password is defined.",
+			)
+		}
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Missing CTFd password",
+				"The provider cannot login to CTFd as there is a missing value for the password, while the username is defined.",
+			)
+		}
+	}
+	if shortLivedToken && !login {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("short_lived_token"),
+			"Invalid CTFd short-lived token mode",
+			"The provider can only create a short-lived API token once logged in with the username and password, but the API key or the session and nonce are defined.",
+		)
+	}
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -175,11 +515,110 @@ func (p *CTFdProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	ctx = utils.AddSensitive(ctx, "ctfd_session", session)
 	ctx = utils.AddSensitive(ctx, "ctfd_nonce", nonce)
 	ctx = utils.AddSensitive(ctx, "ctfd_api_key", apiKey)
+	ctx = utils.AddSensitive(ctx, "ctfd_password", password)
+	sensitive := []string{session, nonce, apiKey, password}
+	for key, values := range headers {
+		ctx = utils.AddSensitive(ctx, "ctfd_header_"+strings.ToLower(key), strings.Join(values, ", "))
+		sensitive = append(sensitive, values...)
+	}
 	tflog.Debug(ctx, "Creating CTFd API client")
 
-	client := api.NewClient(url, session, nonce, apiKey)
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	base, err := transport.New(transport.Options{
+		CACertPEM:          []byte(strings.TrimSpace(caCertPEM)),
+		ClientCertPEM:      []byte(clientCert),
+		ClientKeyPEM:       []byte(clientKey),
+		InsecureSkipVerify: insecureSkipVerify,
+		ProxyURL:           proxyURL,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Provider Error",
+			fmt.Sprintf("Unable to create CTFd API client transport, got error: %s", err),
+		)
+		return
+	}
+	if insecureSkipVerify {
+		tflog.Warn(ctx, "CTFd certificate verification is disabled")
+	}
+
+	// The cleanups on shutdown must be quick, so they are neither retried
+	// nor limited.
+	direct := &transport.Timeout{
+		Next: &transport.Header{
+			Next: &transport.Log{
+				Next:      base,
+				Sensitive: sensitive,
+			},
+			Header: headers,
+		},
+		Timeout: requestTimeout,
+	}
+	// Retries go through the limits too, so they don't overwhelm CTFd
+	rt := &transport.NotFound{
+		Next: &transport.Retry{
+			Next:       transport.NewLimit(direct, requestsPerSecond, int(maxConcurrentRequests)),
+			MaxRetries: int(maxRetries),
+			MinWait:    retryMinWait,
+			MaxWait:    retryMaxWait,
+		},
+	}
+
+	client, err := newClient(url, nonce, session, apiKey, rt)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Provider Error",
+			fmt.Sprintf("Unable to create CTFd API client, got error: %s", err),
+		)
+		return
+	}
+	if login {
+		ctx = tflog.SetField(ctx, "ctfd_username", username)
+		tflog.Debug(ctx, "Logging in to CTFd")
+
+		client, err = loginClient(ctx, url, username, password, rt)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"CTFd Login Error",
+				fmt.Sprintf("Unable to login to CTFd as %s, got error: %s", username, err),
+			)
+			return
+		}
+
+		if shortLivedToken && readOnly {
+			tflog.Info(ctx, "Keeping the CTFd session rather than creating a short-lived API token, as read-only")
+		} else if shortLivedToken {
+			tflog.Debug(ctx, "Creating short-lived CTFd API token")
+
+			if err := shortLivedTokenClient(ctx, client, url, direct); err != nil {
+				resp.Diagnostics.AddError(
+					"Client Error",
+					fmt.Sprintf("Unable to create short-lived API token, got error: %s", err),
+				)
+				return
+			}
+		}
+	}
+
+	// Probe CTFd so misconfigurations are reported here rather than on the first resource
+	authAttr := "api_key"
+	if login {
+		authAttr = "username"
+	} else if apiKey == "" {
+		authAttr = "session"
+	}
+	data, diags := probe(ctx, client, url, authAttr)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ReadOnly = readOnly
+	if readOnly {
+		tflog.Info(ctx, "CTFd provider is read-only")
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.EphemeralResourceData = data
 
 	tflog.Info(ctx, "Configure CTFd API client", map[string]any{
 		"success": true,
@@ -188,13 +627,29 @@ func (p *CTFdProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 
 func (p *CTFdProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewChallengeResource,
 		NewChallengeStandardResource,
 		NewChallengeDynamicResource,
+		NewChallengeMultipleChoiceResource,
 		NewHintResource,
+		NewChallengeSolutionResource,
 		NewFlagResource,
 		NewFileResource,
 		NewUserResource,
 		NewTeamResource,
+		NewPageResource,
+		NewNotificationResource,
+		NewAwardResource,
+		NewConfigResource,
+		NewBracketResource,
+		NewFieldResource,
+		NewCommentResource,
+	}
+}
+
+func (p *CTFdProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewTokenEphemeralResource,
 	}
 }
 
@@ -206,3 +661,285 @@ func (p *CTFdProvider) DataSources(ctx context.Context) []func() datasource.Data
 		NewTeamDataSource,
 	}
 }
+
+// newClient creates a CTFd API client that sends its requests through rt.
+func newClient(url, nonce, session, apiKey string, rt http.RoundTripper) (*api.Client, error) {
+	client := api.NewClient(url, nonce, session, apiKey)
+	sub, err := transport.HTTPClient(client)
+	if err != nil {
+		return nil, err
+	}
+	sub.Transport = rt
+	return client, nil
+}
+
+// loginClient creates a CTFd API client authenticated through the login
+// form, as does a user in its browser.
+func loginClient(ctx context.Context, url, username, password string, rt http.RoundTripper) (*api.Client, error) {
+	nonce, session, err := getNonceAndSession(ctx, url, rt)
+	if err != nil {
+		return nil, fmt.Errorf("getting nonce and session: %w", err)
+	}
+	nonce, session, err = login(ctx, url, nonce, session, username, password, rt)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newClient(url, nonce, session, "", rt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the session is authenticated
+	if _, err := client.GetUsersMe(api.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("getting the logged in user: %w", err)
+	}
+	return client, nil
+}
+
+// errInvalidCredentials is returned when CTFd refuses the login.
+var errInvalidCredentials = errors.New("invalid credentials")
+
+// login submits the login form of CTFd from the anonymous nonce and session,
+// as *api.Client.Login does but with ctx, and returns the authenticated ones.
+func login(ctx context.Context, base, nonce, session, username, password string, rt http.RoundTripper) (string, string, error) {
+	u, err := neturl.Parse(base)
+	if err != nil {
+		return "", "", err
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return "", "", err
+	}
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: session}})
+
+	form := neturl.Values{}
+	form.Set("name", username)
+	form.Set("password", password)
+	form.Set("nonce", nonce)
+	form.Set("_submit", "Submit")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/login", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := (&http.Client{Transport: rt, Jar: jar}).Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("CTFd responded with status code %d", res.StatusCode)
+	}
+	// CTFd redirects once logged in, else renders back the login page
+	if strings.HasSuffix(res.Request.URL.Path, "/login") {
+		return "", "", errInvalidCredentials
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", "", err
+	}
+	newNonce := nonceRegex.Find(body)
+	if newNonce == nil {
+		return "", "", errors.New("nonce not found")
+	}
+	for _, cookie := range jar.Cookies(u) {
+		if cookie.Name == "session" {
+			return string(newNonce), cookie.Value, nil
+		}
+	}
+	return "", "", errors.New("session cookie not found")
+}
+
+// nonceRegex matches the CSRF nonce CTFd renders in its pages.
+var nonceRegex = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// getNonceAndSession returns the initial nonce and session of an anonymous
+// user, as api.GetNonceAndSession does but through rt.
+func getNonceAndSession(ctx context.Context, url string, rt http.RoundTripper) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/login", nil)
+	if err != nil {
+		return "", "", err
+	}
+	res, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", "", err
+	}
+	nonce := nonceRegex.Find(body)
+	if nonce == nil {
+		return "", "", errors.New("nonce not found")
+	}
+	for _, cookie := range res.Cookies() {
+		if cookie.Name == "session" {
+			return string(nonce), cookie.Value, nil
+		}
+	}
+	return "", "", errors.New("session cookie not found")
+}
+
+// shortLivedTokenClient creates an API token for the client that expires
+// the next day, thus is valid for the whole run, and sets the client to
+// use it. The token is deleted once the provider shuts down, through
+// cleanupRT as it must be done before the process is killed.
+func shortLivedTokenClient(ctx context.Context, client *api.Client, url string, cleanupRT http.RoundTripper) error {
+	now := time.Now().UTC()
+	token, err := client.PostTokens(&api.PostTokensParams{
+		Expiration:  now.AddDate(0, 0, 1).Format(time.DateOnly),
+		Description: fmt.Sprintf("Terraform Provider CTFd short-lived token, created at %s.", now.Format(time.RFC3339)),
+	}, api.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if token.Value == nil {
+		return errors.New("CTFd returned no token value")
+	}
+	client.SetAPIKey(*token.Value)
+
+	cleanupClient, err := newClient(url, "", "", *token.Value, cleanupRT)
+	if err != nil {
+		return err
+	}
+	id := strconv.Itoa(token.ID)
+	RegisterCleanup(func(ctx context.Context) error {
+		if err := cleanupClient.DeleteToken(id, api.WithContext(ctx)); err != nil {
+			return fmt.Errorf("deleting short-lived API token %s: %w", id, err)
+		}
+		return nil
+	})
+	return nil
+}
+
+// readOnlyDiagnostic is returned by the resources on creation, update or
+// deletion when the provider is read-only.
+func readOnlyDiagnostic() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Read-Only Provider",
+		"The provider is configured with read_only, so it does not create, update nor delete anything in CTFd. Unset read_only (or CTFD_READ_ONLY) to apply changes.",
+	)
+}
+
+// stringValue returns the value of the attribute if set, else the one of the
+// environment variable.
+func stringValue(v types.String, env string) string {
+	if !v.IsNull() {
+		return v.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// headersValue returns the headers of the attribute if set, else the ones of
+// the environment variable formatted as comma-separated Name=value pairs.
+func headersValue(ctx context.Context, diags *diag.Diagnostics, v types.Map, env string) http.Header {
+	values := map[string]string{}
+	if !v.IsNull() {
+		diags.Append(v.ElementsAs(ctx, &values, false)...)
+	} else if str := os.Getenv(env); str != "" {
+		for _, pair := range strings.Split(str, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				diags.AddAttributeError(
+					path.Root("headers"),
+					"Invalid environment variable",
+					fmt.Sprintf("The environment variable %s must be formatted as comma-separated Name=value pairs, got %q.", env, key),
+				)
+				continue
+			}
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	headers := http.Header{}
+	for key, value := range values {
+		if key == "" || strings.ContainsAny(key, " \t\r\n:") || strings.ContainsAny(value, "\r\n") {
+			diags.AddAttributeError(
+				path.Root("headers"),
+				"Invalid CTFd header",
+				fmt.Sprintf("The header %q is not a valid HTTP header.", key),
+			)
+			continue
+		}
+		headers.Set(key, value)
+	}
+	return headers
+}
+
+// int64Value returns the value of the attribute if set, else the one of the
+// environment variable if set, else the default one.
+func int64Value(diags *diag.Diagnostics, attr string, v types.Int64, env string, def int64) int64 {
+	if !v.IsNull() {
+		return v.ValueInt64()
+	}
+	str, ok := os.LookupEnv(env)
+	if !ok || str == "" {
+		return def
+	}
+	i, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid environment variable",
+			fmt.Sprintf("The environment variable %s must be an integer, got error: %s", env, err),
+		)
+		return def
+	}
+	return i
+}
+
+// float64Value returns the value of the attribute if set, else the one of the
+// environment variable if set, else the default one.
+func float64Value(diags *diag.Diagnostics, attr string, v types.Float64, env string, def float64) float64 {
+	if !v.IsNull() {
+		return v.ValueFloat64()
+	}
+	str, ok := os.LookupEnv(env)
+	if !ok || str == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid environment variable",
+			fmt.Sprintf("The environment variable %s must be a number, got error: %s", env, err),
+		)
+		return def
+	}
+	return f
+}
+
+// durationValue returns the duration of the attribute if set, else the one
+// of the environment variable if set, else the default one.
+func durationValue(diags *diag.Diagnostics, attr string, v types.String, env string, def time.Duration) time.Duration {
+	str, ok := os.LookupEnv(env)
+	if !v.IsNull() {
+		str, ok, env = v.ValueString(), true, ""
+	}
+	if !ok || str == "" {
+		return def
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		detail := fmt.Sprintf("The %s must be a duration (e.g. 1s), got error: %s", attr, err)
+		if env != "" {
+			detail = fmt.Sprintf("The environment variable %s must be a duration (e.g. 1s), got error: %s", env, err)
+		}
+		diags.AddAttributeError(path.Root(attr), "Invalid duration", detail)
+		return def
+	}
+	if d < 0 {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid duration",
+			fmt.Sprintf("The %s must be positive, got %s.", attr, d),
+		)
+		return def
+	}
+	return d
+}
@@ -0,0 +1,67 @@
+package provider_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeCTFd(t, "3.8.0")
+
+	var tests = map[string]struct {
+		Extra         map[string]tftypes.Value
+		ExpectSummary string
+	}{
+		"negative-request-timeout": {
+			Extra: map[string]tftypes.Value{
+				"request_timeout": tftypes.NewValue(tftypes.String, "-1s"),
+			},
+			ExpectSummary: "Invalid duration",
+		},
+		"negative-retry-min-wait": {
+			Extra: map[string]tftypes.Value{
+				"retry_min_wait": tftypes.NewValue(tftypes.String, "-1s"),
+			},
+			ExpectSummary: "Invalid duration",
+		},
+		"negative-retry-max-wait": {
+			Extra: map[string]tftypes.Value{
+				"retry_min_wait": tftypes.NewValue(tftypes.String, "-2s"),
+				"retry_max_wait": tftypes.NewValue(tftypes.String, "-1s"),
+			},
+			ExpectSummary: "Invalid duration",
+		},
+		"missing-ca-cert-file": {
+			Extra: map[string]tftypes.Value{
+				"ca_cert_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "ca.pem")),
+			},
+			ExpectSummary: "Invalid CTFd CA certificates file",
+		},
+		"unknown-proxy-url": {
+			Extra: map[string]tftypes.Value{
+				"proxy_url": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+			ExpectSummary: "Unknown CTFd proxy URL.",
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			_, resp := configureProvider(t, fake.URL, "admin", tt.Extra)
+			if len(resp.Diagnostics) == 0 {
+				t.Fatalf("expected a %q diagnostic, got none", tt.ExpectSummary)
+			}
+			for _, d := range resp.Diagnostics {
+				if d.Summary != tt.ExpectSummary {
+					t.Errorf("expected only %q diagnostics, got %s: %s", tt.ExpectSummary, d.Summary, d.Detail)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPreCheckLogin(t *testing.T) {
+	if os.Getenv("CTFD_USERNAME") == "" || os.Getenv("CTFD_PASSWORD") == "" {
+		t.Skip("CTFD_USERNAME and CTFD_PASSWORD must be set for login acceptance tests")
+	}
+}
+
+func TestAcc_Provider_Login(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckLogin(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "ctfd" {
+	api_key  = ""
+	username = %q
+	password = %q
+}
+
+data "ctfd_challenges_standard" "all" {}
+`, os.Getenv("CTFD_USERNAME"), os.Getenv("CTFD_PASSWORD")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ctfd_challenges_standard.all", "id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "ctfd" {
+	api_key  = ""
+	username = %q
+	password = "not-the-password"
+}
+
+data "ctfd_challenges_standard" "all" {}
+`, os.Getenv("CTFD_USERNAME")),
+				ExpectError: regexp.MustCompile(`invalid credentials`),
+			},
+		},
+	})
+}
+
+func TestAcc_Provider_ShortLivedToken(t *testing.T) {
+	// The provider servers are not stopped during tests, so revoke the
+	// tokens of the run manually.
+	t.Cleanup(func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			t.Errorf("revoking short-lived tokens: %s", err)
+		}
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckLogin(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "ctfd" {
+	api_key           = ""
+	username          = %q
+	password          = %q
+	short_lived_token = true
+}
+
+data "ctfd_challenges_standard" "all" {}
+`, os.Getenv("CTFD_USERNAME"), os.Getenv("CTFD_PASSWORD")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ctfd_challenges_standard.all", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestProvider_ShortLivedTokenCleanup(t *testing.T) {
+	// Not parallel, as it shuts the provider down
+
+	fake := newFakeCTFd(t, "3.7.4")
+	// The token is the "admin" API key, such that the fake CTFd accepts it
+	fake.Mux.HandleFunc("POST /api/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "value": "admin"})
+	})
+	deletes := atomic.Int64{}
+	fake.Mux.HandleFunc("DELETE /api/v1/tokens/1", func(w http.ResponseWriter, r *http.Request) {
+		deletes.Add(1)
+		writeCTFd(w, http.StatusServiceUnavailable, nil)
+	})
+
+	_, resp := configureProvider(t, fake.URL, "", map[string]tftypes.Value{
+		"api_key":           tftypes.NewValue(tftypes.String, nil),
+		"username":          tftypes.NewValue(tftypes.String, "admin"),
+		"password":          tftypes.NewValue(tftypes.String, "admin"),
+		"short_lived_token": tftypes.NewValue(tftypes.Bool, true),
+		"max_retries":       tftypes.NewValue(tftypes.Number, big.NewFloat(3)),
+	})
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	// The token deletion is not retried, as the process is killed soon after
+	if err := provider.Shutdown(context.Background()); err == nil {
+		t.Error("expected the token deletion to fail")
+	}
+	if got := deletes.Load(); got != 1 {
+		t.Errorf("expected a single token deletion attempt, got %d", got)
+	}
+}
+
+func TestProvider_LoginErrors(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeCTFd(t, "3.8.0")
+	// The same CTFd, but failing on a request
+	failing := func(pattern string) string {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method+" "+r.URL.Path == pattern {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fake.Mux.ServeHTTP(w, r)
+		}))
+		t.Cleanup(srv.Close)
+		return srv.URL
+	}
+
+	var tests = map[string]struct {
+		URL          string
+		Password     string
+		ExpectDetail *regexp.Regexp
+	}{
+		"valid": {
+			URL:      fake.URL,
+			Password: "admin",
+		},
+		"invalid-credentials": {
+			URL:          fake.URL,
+			Password:     "wrong",
+			ExpectDetail: regexp.MustCompile(`invalid credentials`),
+		},
+		"login-unavailable": {
+			URL:          failing("POST /login"),
+			Password:     "admin",
+			ExpectDetail: regexp.MustCompile(`status code 503`),
+		},
+		"me-unavailable": {
+			URL:          failing("GET /api/v1/users/me"),
+			Password:     "admin",
+			ExpectDetail: regexp.MustCompile(`getting the logged in user`),
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			_, resp := configureProvider(t, tt.URL, "", map[string]tftypes.Value{
+				"api_key":  tftypes.NewValue(tftypes.String, nil),
+				"username": tftypes.NewValue(tftypes.String, "admin"),
+				"password": tftypes.NewValue(tftypes.String, tt.Password),
+			})
+			if tt.ExpectDetail == nil {
+				for _, d := range resp.Diagnostics {
+					t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 || !tt.ExpectDetail.MatchString(resp.Diagnostics[0].Detail) {
+				t.Errorf("expected a diagnostic matching %s, got %v", tt.ExpectDetail, resp.Diagnostics)
+			}
+		})
+	}
+}
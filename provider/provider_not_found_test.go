@@ -0,0 +1,294 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_NotFound(t *testing.T) {
+	t.Parallel()
+
+	// The fake CTFd responds 404 to any object but the bracket 1, and
+	// fails on the award 2
+	fake := newFakeCTFd(t, "3.8.0")
+	fake.Mux.HandleFunc("GET /api/v1/awards/2", func(w http.ResponseWriter, r *http.Request) {
+		writeCTFd(w, http.StatusInternalServerError, nil)
+	})
+	srv, resp := configureProvider(t, fake.URL, "admin", nil)
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		TypeName     string
+		ID           string
+		ExpectRemove bool
+		ExpectError  bool
+	}{
+		"challenge": {
+			TypeName:     "ctfd_challenge",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"challenge-standard": {
+			TypeName:     "ctfd_challenge_standard",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"challenge-dynamic": {
+			TypeName:     "ctfd_challenge_dynamic",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"challenge-multiple-choice": {
+			TypeName:     "ctfd_challenge_multiple_choice",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"challenge-solution": {
+			TypeName:     "ctfd_challenge_solution",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"hint": {
+			TypeName:     "ctfd_hint",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"flag": {
+			TypeName:     "ctfd_flag",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"file": {
+			TypeName:     "ctfd_file",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"user": {
+			TypeName:     "ctfd_user",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"team": {
+			TypeName:     "ctfd_team",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"page": {
+			TypeName:     "ctfd_page",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"notification": {
+			TypeName:     "ctfd_notification",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"award": {
+			TypeName:     "ctfd_award",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"award-error": {
+			TypeName:    "ctfd_award",
+			ID:          "2",
+			ExpectError: true,
+		},
+		"field": {
+			TypeName:     "ctfd_field",
+			ID:           "1",
+			ExpectRemove: true,
+		},
+		"bracket": {
+			TypeName:     "ctfd_bracket",
+			ID:           "2",
+			ExpectRemove: true,
+		},
+		"bracket-exists": {
+			TypeName: "ctfd_bracket",
+			ID:       "1",
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			readResp, err := srv.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+				TypeName: tt.TypeName,
+				CurrentState: objectValue(t, schema.ResourceSchemas[tt.TypeName], map[string]tftypes.Value{
+					"id": tftypes.NewValue(tftypes.String, tt.ID),
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ExpectError {
+				if len(readResp.Diagnostics) == 0 {
+					t.Error("expected an error, got none")
+				}
+				return
+			}
+			for _, d := range readResp.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			typ := schema.ResourceSchemas[tt.TypeName].ValueType()
+			newState, err := readResp.NewState.Unmarshal(typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if newState.IsNull() != tt.ExpectRemove {
+				t.Errorf("expected resource removal: %t, got state: %s", tt.ExpectRemove, newState)
+			}
+		})
+	}
+}
+
+func TestProvider_NotFoundDelete(t *testing.T) {
+	t.Parallel()
+
+	// The fake CTFd responds 404 to any deletion but the award 2 one,
+	// which fails
+	fake := newFakeCTFd(t, "3.8.0")
+	fake.Mux.HandleFunc("DELETE /api/v1/awards/2", func(w http.ResponseWriter, r *http.Request) {
+		writeCTFd(w, http.StatusInternalServerError, nil)
+	})
+	srv, resp := configureProvider(t, fake.URL, "admin", nil)
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		TypeName    string
+		ID          string
+		ExpectError bool
+	}{
+		"challenge":                 {TypeName: "ctfd_challenge", ID: "1"},
+		"challenge-standard":        {TypeName: "ctfd_challenge_standard", ID: "1"},
+		"challenge-dynamic":         {TypeName: "ctfd_challenge_dynamic", ID: "1"},
+		"challenge-multiple-choice": {TypeName: "ctfd_challenge_multiple_choice", ID: "1"},
+		"challenge-solution":        {TypeName: "ctfd_challenge_solution", ID: "1"},
+		"hint":                      {TypeName: "ctfd_hint", ID: "1"},
+		"flag":                      {TypeName: "ctfd_flag", ID: "1"},
+		"file":                      {TypeName: "ctfd_file", ID: "1"},
+		"user":                      {TypeName: "ctfd_user", ID: "1"},
+		"team":                      {TypeName: "ctfd_team", ID: "1"},
+		"page":                      {TypeName: "ctfd_page", ID: "1"},
+		"notification":              {TypeName: "ctfd_notification", ID: "1"},
+		"comment":                   {TypeName: "ctfd_comment", ID: "1"},
+		"award":                     {TypeName: "ctfd_award", ID: "1"},
+		"award-error":               {TypeName: "ctfd_award", ID: "2", ExpectError: true},
+		"field":                     {TypeName: "ctfd_field", ID: "1"},
+		"bracket":                   {TypeName: "ctfd_bracket", ID: "2"},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			s := schema.ResourceSchemas[tt.TypeName]
+			applyResp, err := srv.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName: tt.TypeName,
+				PriorState: objectValue(t, s, map[string]tftypes.Value{
+					"id": tftypes.NewValue(tftypes.String, tt.ID),
+				}),
+				PlannedState: objectValue(t, s, nil),
+				Config:       objectValue(t, s, nil),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ExpectError {
+				if len(applyResp.Diagnostics) == 0 {
+					t.Error("expected an error, got none")
+				}
+				return
+			}
+			for _, d := range applyResp.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+		})
+	}
+}
+
+func TestProvider_NotFoundChoiceFlag(t *testing.T) {
+	t.Parallel()
+
+	// The fake CTFd knows the challenge 1, but its flag 5 was deleted
+	fake := newFakeCTFd(t, "3.8.0")
+	fake.Mux.HandleFunc("GET /api/v1/challenges/1", func(w http.ResponseWriter, r *http.Request) {
+		writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "name": "Well-known ports", "category": "network", "description": "Which port does HTTPS listen on by default ?\n\n* () 80\n* () 443", "value": 50, "state": "visible", "type": "standard"})
+	})
+	fake.Mux.HandleFunc("GET /api/v1/challenges/1/{sub}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("sub") {
+		case "requirements":
+			writeCTFd(w, http.StatusOK, map[string]any{"prerequisites": []int{}})
+		default:
+			writeCTFd(w, http.StatusOK, []any{})
+		}
+	})
+	srv, resp := configureProvider(t, fake.URL, "admin", nil)
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := schema.ResourceSchemas["ctfd_challenge_multiple_choice"]
+	choicesType := s.ValueType().(tftypes.Object).AttributeTypes["choices"].(tftypes.List)
+	choice := func(text string, correct bool) tftypes.Value {
+		return tftypes.NewValue(choicesType.ElementType, map[string]tftypes.Value{
+			"text":    tftypes.NewValue(tftypes.String, text),
+			"correct": tftypes.NewValue(tftypes.Bool, correct),
+		})
+	}
+
+	readResp, err := srv.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName: "ctfd_challenge_multiple_choice",
+		CurrentState: objectValue(t, s, map[string]tftypes.Value{
+			"id":      tftypes.NewValue(tftypes.String, "1"),
+			"flag_id": tftypes.NewValue(tftypes.String, "5"),
+			"choices": tftypes.NewValue(choicesType, []tftypes.Value{choice("80", false), choice("443", true)}),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range readResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	// The flag is gone, so no choice must be correct anymore for the plan
+	// to differ from the configuration
+	newState, err := readResp.NewState.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]tftypes.Value{
+		"flag_id": tftypes.NewValue(tftypes.String, nil),
+		"choices": tftypes.NewValue(choicesType, []tftypes.Value{choice("80", false), choice("443", false)}),
+	} {
+		v, _, err := tftypes.WalkAttributePath(newState, tftypes.NewAttributePath().WithAttributeName(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !v.(tftypes.Value).Equal(expected) {
+			t.Errorf("expected %s to be %s, got %s", name, expected, v)
+		}
+	}
+}
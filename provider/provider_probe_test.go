@@ -0,0 +1,303 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeCTFd is a local stand-in of a CTFd instance, enough to configure the
+// provider. The "admin" and "user" API keys are accepted, as the login
+// with the "admin" username and password which redirects to the challenges.
+type fakeCTFd struct {
+	*httptest.Server
+
+	// Mux routes the requests, other routes could be added.
+	Mux *http.ServeMux
+
+	// Version returned as the ctf_version configuration.
+	Version string
+
+	// Requests counts the requests other than the probe ones.
+	Requests atomic.Int64
+}
+
+func newFakeCTFd(t *testing.T, version string) *fakeCTFd {
+	mux := http.NewServeMux()
+	f := &fakeCTFd{Mux: mux, Version: version}
+	mux.HandleFunc("GET /login", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "anonymous"})
+		_, _ = w.Write([]byte(`<script>var csrfNonce = "` + strings.Repeat("0", 64) + `";</script>`))
+	})
+	mux.HandleFunc("POST /login", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("name") == "admin" && r.FormValue("password") == "admin" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "admin"})
+			http.Redirect(w, r, "/challenges", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte(`<script>var csrfNonce = "` + strings.Repeat("0", 64) + `";</script>`))
+	})
+	mux.HandleFunc("GET /challenges", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<script>var csrfNonce = "` + strings.Repeat("1", 64) + `";</script>`))
+	})
+	mux.HandleFunc("GET /api/v1/users/me", func(w http.ResponseWriter, r *http.Request) {
+		if name := fakeRole(r); name != "" {
+			writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "name": name})
+			return
+		}
+		writeCTFd(w, http.StatusForbidden, nil)
+	})
+	mux.HandleFunc("GET /api/v1/configs/ctf_version", func(w http.ResponseWriter, r *http.Request) {
+		if fakeRole(r) != "admin" {
+			writeCTFd(w, http.StatusForbidden, nil)
+			return
+		}
+		writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "key": "ctf_version", "value": f.Version})
+	})
+	mux.HandleFunc("POST /api/v1/brackets", func(w http.ResponseWriter, r *http.Request) {
+		f.Requests.Add(1)
+		writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "name": "students", "description": "", "type": "users"})
+	})
+	mux.HandleFunc("GET /api/v1/brackets", func(w http.ResponseWriter, r *http.Request) {
+		f.Requests.Add(1)
+		writeCTFd(w, http.StatusOK, []map[string]any{{"id": 1, "name": "students", "description": "", "type": "users"}})
+	})
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	return f
+}
+
+// fakeRole returns the role of the request to the fake CTFd, either
+// "admin", "user" or empty if it is not authenticated.
+func fakeRole(r *http.Request) string {
+	switch r.Header.Get("Authorization") {
+	case "Token admin":
+		return "admin"
+	case "Token user":
+		return "user"
+	}
+	if c, err := r.Cookie("session"); err == nil && c.Value == "admin" {
+		return "admin"
+	}
+	return ""
+}
+
+// writeCTFd writes a CTFd API response, successful if data is not nil.
+func writeCTFd(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"success": data != nil,
+		"data":    data,
+	})
+}
+
+// objectValue returns the object value of a schema, with the given
+// attributes values and the other ones null, or null if values is nil.
+func objectValue(t *testing.T, schema *tfprotov6.Schema, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	typ := schema.ValueType().(tftypes.Object)
+	if values == nil {
+		dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dv
+	}
+	vals := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+		if v, ok := values[name]; ok {
+			vals[name] = v
+		}
+	}
+	dv, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, vals))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dv
+}
+
+// configureProvider configures a provider server against url with apiKey,
+// without retries, and the extra attributes values.
+func configureProvider(t *testing.T, url, apiKey string, extra map[string]tftypes.Value) (tfprotov6.ProviderServer, *tfprotov6.ConfigureProviderResponse) {
+	srv, err := testAccProtoV6ProviderFactories["ctfd"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]tftypes.Value{
+		"url":         tftypes.NewValue(tftypes.String, url),
+		"api_key":     tftypes.NewValue(tftypes.String, apiKey),
+		"max_retries": tftypes.NewValue(tftypes.Number, big.NewFloat(0)),
+	}
+	for name, v := range extra {
+		values[name] = v
+	}
+	resp, err := srv.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		Config: objectValue(t, schema.Provider, values),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, resp
+}
+
+func TestProvider_Probe(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeCTFd(t, "3.7.4")
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	notCTFd := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(notCTFd.Close)
+
+	var tests = map[string]struct {
+		URL            string
+		APIKey         string
+		ExpectSeverity tfprotov6.DiagnosticSeverity
+		ExpectSummary  string
+		ExpectAttr     string
+	}{
+		"admin": {
+			URL:    fake.URL,
+			APIKey: "admin",
+		},
+		"non-admin": {
+			URL:            fake.URL,
+			APIKey:         "user",
+			ExpectSeverity: tfprotov6.DiagnosticSeverityWarning,
+			ExpectSummary:  "Non-admin CTFd credentials",
+			ExpectAttr:     "api_key",
+		},
+		"invalid-credentials": {
+			URL:            fake.URL,
+			APIKey:         "expired",
+			ExpectSeverity: tfprotov6.DiagnosticSeverityError,
+			ExpectSummary:  "Invalid CTFd credentials",
+			ExpectAttr:     "api_key",
+		},
+		"unreachable": {
+			URL:            closed.URL,
+			APIKey:         "admin",
+			ExpectSeverity: tfprotov6.DiagnosticSeverityError,
+			ExpectSummary:  "Unreachable CTFd",
+			ExpectAttr:     "url",
+		},
+		"not-ctfd": {
+			URL:            notCTFd.URL,
+			APIKey:         "admin",
+			ExpectSeverity: tfprotov6.DiagnosticSeverityError,
+			ExpectSummary:  "CTFd API not found",
+			ExpectAttr:     "url",
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			_, resp := configureProvider(t, tt.URL, tt.APIKey, nil)
+
+			if tt.ExpectSummary == "" {
+				for _, d := range resp.Diagnostics {
+					t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 1 {
+				t.Fatalf("expected 1 diagnostic, got %d: %v", len(resp.Diagnostics), resp.Diagnostics)
+			}
+			d := resp.Diagnostics[0]
+			if d.Severity != tt.ExpectSeverity || d.Summary != tt.ExpectSummary {
+				t.Errorf("expected %s %q, got %s %q: %s", tt.ExpectSeverity, tt.ExpectSummary, d.Severity, d.Summary, d.Detail)
+			}
+			if d.Attribute == nil || d.Attribute.String() != tftypes.NewAttributePath().WithAttributeName(tt.ExpectAttr).String() {
+				t.Errorf("expected diagnostic on attribute %s, got %v", tt.ExpectAttr, d.Attribute)
+			}
+		})
+	}
+}
+
+func TestProvider_RequireVersion(t *testing.T) {
+	t.Parallel()
+
+	var tests = map[string]struct {
+		Version      string
+		ExpectError  bool
+		ExpectPosted int64
+	}{
+		"unsupported": {
+			Version:     "3.6.1",
+			ExpectError: true,
+		},
+		"supported": {
+			Version:      "3.7.0",
+			ExpectPosted: 1,
+		},
+		"unknown": {
+			Version:      "",
+			ExpectPosted: 1,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			fake := newFakeCTFd(t, tt.Version)
+			srv, resp := configureProvider(t, fake.URL, "admin", nil)
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			bracket := schema.ResourceSchemas["ctfd_bracket"]
+			values := map[string]tftypes.Value{
+				"name":        tftypes.NewValue(tftypes.String, "students"),
+				"description": tftypes.NewValue(tftypes.String, ""),
+				"type":        tftypes.NewValue(tftypes.String, "users"),
+			}
+			config := objectValue(t, bracket, values)
+			values["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			applyResp, err := srv.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     "ctfd_bracket",
+				PriorState:   objectValue(t, bracket, nil),
+				PlannedState: objectValue(t, bracket, values),
+				Config:       config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hasError := false
+			for _, d := range applyResp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					hasError = true
+					if d.Summary != "Unsupported CTFd Version" {
+						t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+					}
+				}
+			}
+			if hasError != tt.ExpectError {
+				t.Errorf("expected error: %t, got: %v", tt.ExpectError, applyResp.Diagnostics)
+			}
+			if posted := fake.Requests.Load(); posted != tt.ExpectPosted {
+				t.Errorf("expected %d bracket creation requests, got %d", tt.ExpectPosted, posted)
+			}
+		})
+	}
+}
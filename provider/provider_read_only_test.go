@@ -0,0 +1,122 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeCTFd(t, "3.7.4")
+	srv, resp := configureProvider(t, fake.URL, "admin", map[string]tftypes.Value{
+		"read_only": tftypes.NewValue(tftypes.Bool, true),
+	})
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bracket := schema.ResourceSchemas["ctfd_bracket"]
+	values := map[string]tftypes.Value{
+		"name":        tftypes.NewValue(tftypes.String, "students"),
+		"description": tftypes.NewValue(tftypes.String, ""),
+		"type":        tftypes.NewValue(tftypes.String, "users"),
+	}
+	config := objectValue(t, bracket, values)
+	values["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	planned := objectValue(t, bracket, values)
+	values["id"] = tftypes.NewValue(tftypes.String, "1")
+	state := objectValue(t, bracket, values)
+
+	// Create and delete are refused before reaching CTFd
+	for name, req := range map[string]*tfprotov6.ApplyResourceChangeRequest{
+		"create": {
+			TypeName:     "ctfd_bracket",
+			PriorState:   objectValue(t, bracket, nil),
+			PlannedState: planned,
+			Config:       config,
+		},
+		"delete": {
+			TypeName:     "ctfd_bracket",
+			PriorState:   state,
+			PlannedState: objectValue(t, bracket, nil),
+			Config:       objectValue(t, bracket, nil),
+		},
+	} {
+		applyResp, err := srv.ApplyResourceChange(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applyResp.Diagnostics) != 1 || applyResp.Diagnostics[0].Summary != "Read-Only Provider" {
+			t.Errorf("%s: expected a read-only error, got %v", name, applyResp.Diagnostics)
+		}
+	}
+	if requests := fake.Requests.Load(); requests != 0 {
+		t.Fatalf("expected no request to CTFd, got %d", requests)
+	}
+
+	// Read keeps working
+	readResp, err := srv.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     "ctfd_bracket",
+		CurrentState: state,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range readResp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if requests := fake.Requests.Load(); requests != 1 {
+		t.Errorf("expected the read to reach CTFd, got %d requests", requests)
+	}
+}
+
+func TestProvider_ReadOnlyTokens(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeCTFd(t, "3.7.4")
+	fake.Mux.HandleFunc("POST /api/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		fake.Requests.Add(1)
+		writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "value": "ctfd_sometoken"})
+	})
+
+	// The short-lived token mode keeps the login session
+	srv, resp := configureProvider(t, fake.URL, "", map[string]tftypes.Value{
+		"api_key":           tftypes.NewValue(tftypes.String, nil),
+		"username":          tftypes.NewValue(tftypes.String, "admin"),
+		"password":          tftypes.NewValue(tftypes.String, "admin"),
+		"short_lived_token": tftypes.NewValue(tftypes.Bool, true),
+		"read_only":         tftypes.NewValue(tftypes.Bool, true),
+	})
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	// The ephemeral token is not created, but does not fail the plan
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	openResp, err := srv.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "ctfd_token",
+		Config:   objectValue(t, schema.EphemeralResourceSchemas["ctfd_token"], map[string]tftypes.Value{}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(openResp.Diagnostics) != 1 || openResp.Diagnostics[0].Severity != tfprotov6.DiagnosticSeverityWarning {
+		t.Errorf("expected a read-only warning, got %v", openResp.Diagnostics)
+	}
+
+	if requests := fake.Requests.Load(); requests != 0 {
+		t.Errorf("expected no POST /api/v1/tokens, got %d", requests)
+	}
+}
@@ -0,0 +1,345 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_NestedReadError(t *testing.T) {
+	t.Parallel()
+
+	var tests = map[string]struct {
+		TypeName   string
+		DataSource bool
+		Failing    string
+	}{
+		"challenge-requirements": {
+			TypeName: "ctfd_challenge",
+			Failing:  "requirements",
+		},
+		"challenge-standard-requirements": {
+			TypeName: "ctfd_challenge_standard",
+			Failing:  "requirements",
+		},
+		"challenge-standard-tags": {
+			TypeName: "ctfd_challenge_standard",
+			Failing:  "tags",
+		},
+		"challenge-standard-topics": {
+			TypeName: "ctfd_challenge_standard",
+			Failing:  "topics",
+		},
+		"challenge-dynamic-tags": {
+			TypeName: "ctfd_challenge_dynamic",
+			Failing:  "tags",
+		},
+		"challenge-dynamic-topics": {
+			TypeName: "ctfd_challenge_dynamic",
+			Failing:  "topics",
+		},
+		"challenge-multiple-choice-topics": {
+			TypeName: "ctfd_challenge_multiple_choice",
+			Failing:  "topics",
+		},
+		"challenges-standard-tags": {
+			TypeName:   "ctfd_challenges_standard",
+			DataSource: true,
+			Failing:    "tags",
+		},
+		"challenges-dynamic-requirements": {
+			TypeName:   "ctfd_challenges_dynamic",
+			DataSource: true,
+			Failing:    "requirements",
+		},
+		"file-challenge-files": {
+			TypeName: "ctfd_file",
+			Failing:  "files",
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			// The fake CTFd knows the challenge 1 and the file 1, but fails
+			// on one of the challenge subresources.
+			fake := newFakeCTFd(t, "3.8.0")
+			challenge := map[string]any{"id": 1, "name": "Some challenge", "category": "misc", "description": "Some description", "value": 500, "initial": 500, "decay": 10, "minimum": 100, "function": "linear", "state": "hidden", "type": "standard"}
+			fake.Mux.HandleFunc("GET /api/v1/challenges", func(w http.ResponseWriter, r *http.Request) {
+				writeCTFd(w, http.StatusOK, []map[string]any{challenge})
+			})
+			fake.Mux.HandleFunc("GET /api/v1/challenges/1", func(w http.ResponseWriter, r *http.Request) {
+				writeCTFd(w, http.StatusOK, challenge)
+			})
+			fake.Mux.HandleFunc("GET /api/v1/challenges/1/{sub}", func(w http.ResponseWriter, r *http.Request) {
+				switch r.PathValue("sub") {
+				case tt.Failing:
+					writeCTFd(w, http.StatusInternalServerError, nil)
+				case "requirements":
+					writeCTFd(w, http.StatusOK, map[string]any{"prerequisites": []int{}})
+				default:
+					writeCTFd(w, http.StatusOK, []any{})
+				}
+			})
+			fake.Mux.HandleFunc("GET /api/v1/files/1", func(w http.ResponseWriter, r *http.Request) {
+				writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "type": "challenge", "location": "0123456789abcdef/flag.txt", "sha1sum": "da39a3ee5e6b4b0d3255bfef95601890afd80709"})
+			})
+
+			srv, resp := configureProvider(t, fake.URL, "admin", nil)
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var diags []*tfprotov6.Diagnostic
+			if tt.DataSource {
+				readResp, err := srv.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+					TypeName: tt.TypeName,
+					Config:   objectValue(t, schema.DataSourceSchemas[tt.TypeName], map[string]tftypes.Value{}),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				diags = readResp.Diagnostics
+			} else {
+				readResp, err := srv.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+					TypeName: tt.TypeName,
+					CurrentState: objectValue(t, schema.ResourceSchemas[tt.TypeName], map[string]tftypes.Value{
+						"id": tftypes.NewValue(tftypes.String, "1"),
+					}),
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				diags = readResp.Diagnostics
+			}
+
+			if len(diags) == 0 || diags[0].Severity != tfprotov6.DiagnosticSeverityError {
+				t.Errorf("expected the %s read failure to abort, got diagnostics: %v", tt.Failing, diags)
+			}
+		})
+	}
+}
+
+func TestProvider_ChallengeSingleRead(t *testing.T) {
+	t.Parallel()
+
+	// The fake CTFd knows the dynamic challenge 1, which the generic
+	// ctfd_challenge resource must read in a single request.
+	fake := newFakeCTFd(t, "3.8.0")
+	gets := atomic.Int64{}
+	fake.Mux.HandleFunc("GET /api/v1/challenges/1", func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "name": "Some challenge", "category": "misc", "description": "Some description", "value": 500, "initial": 500, "decay": 10, "minimum": 100, "function": "linear", "state": "hidden", "type": "dynamic"})
+	})
+	fake.Mux.HandleFunc("GET /api/v1/challenges/1/{sub}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("sub") {
+		case "requirements":
+			writeCTFd(w, http.StatusOK, map[string]any{"prerequisites": []int{}})
+		default:
+			writeCTFd(w, http.StatusOK, []any{})
+		}
+	})
+
+	srv, resp := configureProvider(t, fake.URL, "admin", nil)
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := schema.ResourceSchemas["ctfd_challenge"]
+
+	readResp, err := srv.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName: "ctfd_challenge",
+		CurrentState: objectValue(t, s, map[string]tftypes.Value{
+			"id": tftypes.NewValue(tftypes.String, "1"),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range readResp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	if got := gets.Load(); got != 1 {
+		t.Errorf("expected the challenge to be read once, got %d reads", got)
+	}
+	state, err := readResp.NewState.Unmarshal(s.ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "Some challenge"),
+		"value": tftypes.NewValue(tftypes.Number, 500),
+		"type":  tftypes.NewValue(tftypes.String, "dynamic"),
+	} {
+		v, _, err := tftypes.WalkAttributePath(state, tftypes.NewAttributePath().WithAttributeName(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !v.(tftypes.Value).Equal(expected) {
+			t.Errorf("expected %s to be %s, got %s", name, expected, v)
+		}
+	}
+}
+
+func TestProvider_DuplicateTopics(t *testing.T) {
+	t.Parallel()
+
+	// The fake CTFd knows the challenge 1, with the same tag and topic
+	// attached twice
+	fake := newFakeCTFd(t, "3.8.0")
+	fake.Mux.HandleFunc("GET /api/v1/challenges/1", func(w http.ResponseWriter, r *http.Request) {
+		writeCTFd(w, http.StatusOK, map[string]any{"id": 1, "name": "Some challenge", "category": "misc", "description": "Some description", "value": 500, "initial": 500, "decay": 10, "minimum": 100, "function": "linear", "state": "hidden", "type": "dynamic"})
+	})
+	fake.Mux.HandleFunc("GET /api/v1/challenges/1/{sub}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("sub") {
+		case "requirements":
+			writeCTFd(w, http.StatusOK, map[string]any{"prerequisites": []int{}})
+		case "tags":
+			writeCTFd(w, http.StatusOK, []map[string]any{{"id": 1, "challenge_id": 1, "value": "network"}, {"id": 2, "challenge_id": 1, "value": "network"}})
+		case "topics":
+			writeCTFd(w, http.StatusOK, []map[string]any{{"id": 1, "challenge_id": 1, "topic_id": 1, "value": "icmp"}, {"id": 2, "challenge_id": 1, "topic_id": 1, "value": "icmp"}})
+		default:
+			writeCTFd(w, http.StatusOK, []any{})
+		}
+	})
+
+	srv, resp := configureProvider(t, fake.URL, "admin", nil)
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, typeName := range []string{"ctfd_challenge", "ctfd_challenge_standard", "ctfd_challenge_dynamic"} {
+		t.Run(typeName, func(t *testing.T) {
+			t.Parallel()
+
+			s := schema.ResourceSchemas[typeName]
+			readResp, err := srv.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+				TypeName: typeName,
+				CurrentState: objectValue(t, s, map[string]tftypes.Value{
+					"id": tftypes.NewValue(tftypes.String, "1"),
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range readResp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			state, err := readResp.NewState.Unmarshal(s.ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, _, err := tftypes.WalkAttributePath(state, tftypes.NewAttributePath().WithAttributeName("topics"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "icmp"),
+			})
+			if !v.(tftypes.Value).Equal(expected) {
+				t.Errorf("expected topics to be %s, got %s", expected, v)
+			}
+		})
+	}
+}
+
+func TestProvider_CommentPagination(t *testing.T) {
+	t.Parallel()
+
+	// The fake CTFd paginates the comments of the challenge 1 by one
+	fake := newFakeCTFd(t, "3.8.0")
+	fake.Mux.HandleFunc("GET /api/v1/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("challenge_id") != "1" {
+			writeCTFd(w, http.StatusBadRequest, nil)
+			return
+		}
+		var data []map[string]any
+		meta := map[string]any{"page": 2, "next": nil, "pages": 2, "per_page": 1, "total": 2}
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			data = []map[string]any{{"id": 1, "content": "First comment", "type": "challenge"}}
+			meta["page"], meta["next"] = 1, 2
+		case "2":
+			data = []map[string]any{{"id": 2, "content": "Second comment", "type": "challenge"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": true,
+			"data":    data,
+			"meta":    map[string]any{"pagination": meta},
+		})
+	})
+
+	srv, resp := configureProvider(t, fake.URL, "admin", nil)
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := schema.ResourceSchemas["ctfd_comment"]
+
+	var tests = map[string]struct {
+		ID           string
+		ExpectRemove bool
+	}{
+		"first-page": {
+			ID: "1",
+		},
+		"second-page": {
+			ID: "2",
+		},
+		"not-found": {
+			ID:           "3",
+			ExpectRemove: true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			readResp, err := srv.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+				TypeName: "ctfd_comment",
+				CurrentState: objectValue(t, s, map[string]tftypes.Value{
+					"id":           tftypes.NewValue(tftypes.String, tt.ID),
+					"challenge_id": tftypes.NewValue(tftypes.String, "1"),
+				}),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range readResp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			state, err := readResp.NewState.Unmarshal(s.ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.IsNull() != tt.ExpectRemove {
+				t.Errorf("expected resource removal: %t, got state: %s", tt.ExpectRemove, state)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package provider_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_CreateRollback(t *testing.T) {
+	t.Parallel()
+
+	strings := func(values ...string) tftypes.Value {
+		elems := make([]tftypes.Value, 0, len(values))
+		for _, v := range values {
+			elems = append(elems, tftypes.NewValue(tftypes.String, v))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elems)
+	}
+	challenge := map[string]tftypes.Value{
+		"name":            tftypes.NewValue(tftypes.String, "icmp"),
+		"category":        tftypes.NewValue(tftypes.String, "network"),
+		"description":     tftypes.NewValue(tftypes.String, "Find the flag in the packets."),
+		"connection_info": tftypes.NewValue(tftypes.String, ""),
+		"max_attempts":    tftypes.NewValue(tftypes.Number, big.NewFloat(0)),
+		"value":           tftypes.NewValue(tftypes.Number, big.NewFloat(500)),
+		"state":           tftypes.NewValue(tftypes.String, "hidden"),
+		"tags":            strings("network"),
+		"topics":          strings(),
+	}
+	team := map[string]tftypes.Value{
+		"name":     tftypes.NewValue(tftypes.String, "cybercombattants"),
+		"email":    tftypes.NewValue(tftypes.String, "team@ctfer.io"),
+		"password": tftypes.NewValue(tftypes.String, "password"),
+		"hidden":   tftypes.NewValue(tftypes.Bool, false),
+		"banned":   tftypes.NewValue(tftypes.Bool, false),
+		"members":  strings("1"),
+		"captain":  tftypes.NewValue(tftypes.String, "1"),
+	}
+
+	var tests = map[string]struct {
+		TypeName      string
+		Values        map[string]tftypes.Value
+		Failing       string
+		Parent        string
+		DeleteFails   bool
+		ExpectSummary string
+		ExpectID      bool
+	}{
+		"challenge-deleted": {
+			TypeName:      "ctfd_challenge_standard",
+			Values:        challenge,
+			Failing:       "POST /api/v1/tags",
+			Parent:        "/api/v1/challenges",
+			ExpectSummary: "Challenge Creation Rolled Back",
+		},
+		"challenge-kept": {
+			TypeName:      "ctfd_challenge_standard",
+			Values:        challenge,
+			Failing:       "POST /api/v1/tags",
+			Parent:        "/api/v1/challenges",
+			DeleteFails:   true,
+			ExpectSummary: "Partially Created Challenge",
+			ExpectID:      true,
+		},
+		"team-deleted": {
+			TypeName:      "ctfd_team",
+			Values:        team,
+			Failing:       "POST /api/v1/teams/1/members",
+			Parent:        "/api/v1/teams",
+			ExpectSummary: "Team Creation Rolled Back",
+		},
+		"team-kept": {
+			TypeName:      "ctfd_team",
+			Values:        team,
+			Failing:       "PATCH /api/v1/teams/1",
+			Parent:        "/api/v1/teams",
+			DeleteFails:   true,
+			ExpectSummary: "Partially Created Team",
+			ExpectID:      true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			// The fake CTFd creates the parent object 1, then fails on one of
+			// its sub-steps.
+			fake := newFakeCTFd(t, "3.8.0")
+			deletes := atomic.Int64{}
+			fake.Mux.HandleFunc("POST "+tt.Parent, func(w http.ResponseWriter, r *http.Request) {
+				writeCTFd(w, http.StatusOK, map[string]any{"id": 1})
+			})
+			for _, step := range []string{"POST /api/v1/tags", "POST /api/v1/teams/1/members", "PATCH /api/v1/teams/1"} {
+				fake.Mux.HandleFunc(step, func(w http.ResponseWriter, r *http.Request) {
+					if step == tt.Failing {
+						writeCTFd(w, http.StatusInternalServerError, nil)
+						return
+					}
+					writeCTFd(w, http.StatusOK, map[string]any{"id": 1})
+				})
+			}
+			fake.Mux.HandleFunc("DELETE "+tt.Parent+"/1", func(w http.ResponseWriter, r *http.Request) {
+				deletes.Add(1)
+				if tt.DeleteFails {
+					writeCTFd(w, http.StatusInternalServerError, nil)
+					return
+				}
+				writeCTFd(w, http.StatusOK, map[string]any{})
+			})
+
+			srv, resp := configureProvider(t, fake.URL, "admin", nil)
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := schema.ResourceSchemas[tt.TypeName]
+
+			values := map[string]tftypes.Value{}
+			for k, v := range tt.Values {
+				values[k] = v
+			}
+			config := objectValue(t, s, values)
+			values["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+			applyResp, err := srv.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+				TypeName:     tt.TypeName,
+				PriorState:   objectValue(t, s, nil),
+				PlannedState: objectValue(t, s, values),
+				Config:       config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := deletes.Load(); got != 1 {
+				t.Errorf("expected the %s to be deleted once, got %d deletions", tt.TypeName, got)
+			}
+			found := false
+			for _, d := range applyResp.Diagnostics {
+				found = found || d.Summary == tt.ExpectSummary
+			}
+			if !found {
+				t.Errorf("expected a %q diagnostic, got %v", tt.ExpectSummary, applyResp.Diagnostics)
+			}
+
+			newState, err := applyResp.NewState.Unmarshal(s.ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.ExpectID {
+				if !newState.IsNull() {
+					t.Errorf("expected no state, got %s", newState)
+				}
+				return
+			}
+			id, _, err := tftypes.WalkAttributePath(newState, tftypes.NewAttributePath().WithAttributeName("id"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !id.(tftypes.Value).Equal(tftypes.NewValue(tftypes.String, "1")) {
+				t.Errorf("expected the partial state to hold the ID, got %s", newState)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_UpgradeListsToSets(t *testing.T) {
+	t.Parallel()
+
+	srv, err := testAccProtoV6ProviderFactories["ctfd"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := srv.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		TypeName string
+		State    string
+		Path     *tftypes.AttributePath
+		Expect   []string
+	}{
+		"challenge-standard-tags": {
+			TypeName: "ctfd_challenge_standard",
+			State:    `{"id":"1","name":"icmp","tags":["network","easy","network"],"topics":[]}`,
+			Path:     tftypes.NewAttributePath().WithAttributeName("tags"),
+			Expect:   []string{"network", "easy"},
+		},
+		"challenge-dynamic-prerequisites": {
+			TypeName: "ctfd_challenge_dynamic",
+			State:    `{"id":"2","name":"icmp","requirements":{"behavior":"hidden","prerequisites":["1"]}}`,
+			Path:     tftypes.NewAttributePath().WithAttributeName("requirements").WithAttributeName("prerequisites"),
+			Expect:   []string{"1"},
+		},
+		"challenge-dynamic-no-requirements": {
+			TypeName: "ctfd_challenge_dynamic",
+			State:    `{"id":"2","name":"icmp","requirements":null,"topics":["network"]}`,
+			Path:     tftypes.NewAttributePath().WithAttributeName("topics"),
+			Expect:   []string{"network"},
+		},
+		"hint-requirements": {
+			TypeName: "ctfd_hint",
+			State:    `{"id":"3","challenge_id":"1","content":"Look at the packets","requirements":["1","2"]}`,
+			Path:     tftypes.NewAttributePath().WithAttributeName("requirements"),
+			Expect:   []string{"1", "2"},
+		},
+		"team-members": {
+			TypeName: "ctfd_team",
+			State:    `{"id":"1","name":"cybercombattants","members":["2","1"],"captain":"1"}`,
+			Path:     tftypes.NewAttributePath().WithAttributeName("members"),
+			Expect:   []string{"2", "1"},
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := srv.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: tt.TypeName,
+				Version:  0,
+				RawState: &tfprotov6.RawState{
+					JSON: []byte(tt.State),
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+
+			state, err := resp.UpgradedState.Unmarshal(schema.ResourceSchemas[tt.TypeName].ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, _, err := tftypes.WalkAttributePath(state, tt.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			set, ok := v.(tftypes.Value)
+			if !ok || !set.Type().Is(tftypes.Set{}) {
+				t.Fatalf("expected a set, got %v", v)
+			}
+			var elems []tftypes.Value
+			if err := set.As(&elems); err != nil {
+				t.Fatal(err)
+			}
+			if len(elems) != len(tt.Expect) {
+				t.Fatalf("expected %v, got %v", tt.Expect, elems)
+			}
+			for i, elem := range elems {
+				if !elem.Equal(tftypes.NewValue(tftypes.String, tt.Expect[i])) {
+					t.Errorf("expected %v, got %v", tt.Expect, elems)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// rollbackCreate compensates the creation of an object whose sub-steps
+// failed (e.g. a challenge and its tags), such that retrying never
+// duplicates it in CTFd.
+// The object is deleted with del, or if it fails, its ID is saved as
+// the partial state. Terraform then marks it as tainted and replaces it
+// on the next apply.
+func rollbackCreate(ctx context.Context, resp *resource.CreateResponse, kind, id string, del func(context.Context) error) {
+	// Still try to delete once the operation is cancelled, as it is
+	// a common cause of failure.
+	if err := del(context.WithoutCancel(ctx)); err != nil {
+		resp.Diagnostics.AddError(
+			"Partially Created "+kind,
+			fmt.Sprintf("The %s %s failed to be created and could not be deleted, got error: %s\n\n"+
+				"It is saved in state as tainted, such that it is replaced on the next apply.", strings.ToLower(kind), id, err),
+		)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(id))...)
+		return
+	}
+	tflog.Debug(ctx, "rolled back the creation", map[string]any{
+		"kind": strings.ToLower(kind),
+		"id":   id,
+	})
+	resp.Diagnostics.AddWarning(
+		kind+" Creation Rolled Back",
+		fmt.Sprintf("The %s %s failed to be created thus has been deleted. It will be created again on the next apply.", strings.ToLower(kind), id),
+	)
+}
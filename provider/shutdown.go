@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// cleanups are the actions to run once the provider process shuts down,
+// e.g. revoking the short-lived API tokens created on Configure.
+var (
+	cleanupsMx sync.Mutex
+	cleanups   []func(ctx context.Context) error
+)
+
+// RegisterCleanup adds an action to run on Shutdown.
+func RegisterCleanup(f func(ctx context.Context) error) {
+	cleanupsMx.Lock()
+	defer cleanupsMx.Unlock()
+
+	cleanups = append(cleanups, f)
+}
+
+// Shutdown runs the registered cleanups, in reverse order of registration.
+// It must be called once the provider server stopped serving.
+func Shutdown(ctx context.Context) error {
+	cleanupsMx.Lock()
+	defer cleanupsMx.Unlock()
+
+	var errs []error
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		if err := cleanups[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	cleanups = nil
+	return errors.Join(errs...)
+}
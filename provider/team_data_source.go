@@ -73,7 +73,7 @@ func (team *teamDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				MarkdownDescription: "Is true if the team is banned from the CTF.",
 				Computed:            true,
 			},
-			"members": schema.ListAttribute{
+			"members": schema.SetAttribute{
 				MarkdownDescription: "List of members (User), defined by their IDs.",
 				ElementType:         types.StringType,
 				Computed:            true,
@@ -91,16 +91,16 @@ func (team *teamDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	team.client = client
+	team.client = data.Client
 }
 
 func (team *teamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -7,11 +7,13 @@ import (
 
 	"github.com/ctfer-io/go-ctfd/api"
 	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,9 +21,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*teamResource)(nil)
-	_ resource.ResourceWithConfigure   = (*teamResource)(nil)
-	_ resource.ResourceWithImportState = (*teamResource)(nil)
+	_ resource.Resource                 = (*teamResource)(nil)
+	_ resource.ResourceWithConfigure    = (*teamResource)(nil)
+	_ resource.ResourceWithImportState  = (*teamResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*teamResource)(nil)
 )
 
 type teamResourceModel struct {
@@ -36,6 +39,8 @@ type teamResourceModel struct {
 	Banned      types.Bool     `tfsdk:"banned"`
 	Members     []types.String `tfsdk:"members"`
 	Captain     types.String   `tfsdk:"captain"`
+	BracketID   types.String   `tfsdk:"bracket_id"`
+	Fields      types.Map      `tfsdk:"fields"`
 }
 
 func NewTeamResource() resource.Resource {
@@ -43,7 +48,9 @@ func NewTeamResource() resource.Resource {
 }
 
 type teamResource struct {
-	client *api.Client
+	client   *api.Client
+	version  *ctfdVersion
+	readOnly bool
 }
 
 func (r *teamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,6 +60,8 @@ func (r *teamResource) Metadata(ctx context.Context, req resource.MetadataReques
 func (r *teamResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "CTFd defines a Team as a group of Users who will attend the Capture The Flag event.",
+		// Version 1 turned members from a list into a set.
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Identifier of the user.",
@@ -100,7 +109,7 @@ func (r *teamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				Default:             defaults.Bool(booldefault.StaticBool(false)),
 			},
-			"members": schema.ListAttribute{
+			"members": schema.SetAttribute{
 				MarkdownDescription: "List of members (User), defined by their IDs.",
 				ElementType:         types.StringType,
 				Required:            true,
@@ -109,6 +118,19 @@ func (r *teamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Member who is captain of the team. Must be part of the members too. Note it could cause a fatal error in case of resource import with an inconsistent CTFd configuration i.e. if a team has no captain yet (should not be possible).",
 				Required:            true,
 			},
+			"bracket_id": schema.StringAttribute{
+				MarkdownDescription: "Bracket the team is part of, must be of type teams. Requires CTFd 3.7 or later.",
+				Optional:            true,
+			},
+			"fields": schema.MapAttribute{
+				MarkdownDescription: "Values of the custom fields (`ctfd_field` of scope team), indexed by the field ID. Boolean values are either `true` or `false`. If not set, the values are left untouched.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -119,73 +141,104 @@ func (r *teamResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.version = data.Version
+	r.readOnly = data.ReadOnly
+}
+
+// The following types complete github.com/ctfer-io/go-ctfd/api with the
+// bracket and the fields values of the team.
+
+type ctfdTeam struct {
+	api.Team
+
+	BracketID *int         `json:"bracket_id"`
+	Fields    []fieldEntry `json:"fields"`
+}
+
+type postTeamsParams struct {
+	api.PostTeamsParams
+
+	BracketID *int         `json:"bracket_id,omitempty"`
+	Fields    []fieldEntry `json:"fields,omitempty"`
+}
+
+type patchTeamsParams struct {
+	api.PatchTeamsParams
+
+	// BracketID is always sent, such that null removes the team from its bracket.
+	BracketID *int `json:"bracket_id"`
+	// Fields are not sent if not managed, else CTFd would erase them.
+	Fields []fieldEntry `json:"fields,omitempty"`
+}
+
+// patchTeamCaptainParams only sets the captain of the team, as it
+// must be a member first.
+type patchTeamCaptainParams struct {
+	CaptainID int `json:"captain_id"`
 }
 
 func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data teamResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if !data.BracketID.IsNull() {
+		requireVersion(&resp.Diagnostics, r.version, "Team brackets", 3, 7)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	res, err := r.client.PostTeams(&api.PostTeamsParams{
-		Name:        data.Name.ValueString(),
-		Email:       data.Email.ValueString(),
-		Password:    data.Password.ValueString(),
-		Website:     data.Website.ValueStringPointer(),
-		Affiliation: data.Affiliation.ValueStringPointer(),
-		Country:     data.Country.ValueStringPointer(),
-		Hidden:      data.Hidden.ValueBool(),
-		Banned:      data.Banned.ValueBool(),
-		Fields:      []api.Field{},
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Client Error",
-			fmt.Sprintf("Unable to create team, got error: %s", err),
-		)
+	fields, diags := fieldEntries(ctx, r.client, data.Fields, types.MapNull(types.StringType))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	data.ID = types.StringValue(strconv.Itoa(res.ID))
-
-	// => Members
-	for _, mem := range data.Members {
-		_, err := r.client.PostTeamMembers(res.ID, &api.PostTeamsMembersParams{
-			UserID: utils.Atoi(mem.ValueString()),
-		}, api.WithContext(ctx))
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Client Error",
-				fmt.Sprintf("Unable to add user to team %d, got error: %s", res.ID, err),
-			)
-			return
-		}
-	}
-	// => Captain
-	cap := utils.Atoi(data.Captain.ValueString())
-	if _, err := r.client.PatchTeam(res.ID, &api.PatchTeamsParams{
-		CaptainID: &cap,
-		Fields:    []api.Field{},
-	}); err != nil {
+	res := &ctfdTeam{}
+	if err := apiPost(ctx, r.client, "/teams", &postTeamsParams{
+		PostTeamsParams: api.PostTeamsParams{
+			Name:        data.Name.ValueString(),
+			Email:       data.Email.ValueString(),
+			Password:    data.Password.ValueString(),
+			Website:     data.Website.ValueStringPointer(),
+			Affiliation: data.Affiliation.ValueStringPointer(),
+			Country:     data.Country.ValueStringPointer(),
+			Hidden:      data.Hidden.ValueBool(),
+			Banned:      data.Banned.ValueBool(),
+		},
+		BracketID: bracketID(data.BracketID),
+		Fields:    fields,
+	}, res); err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
-			fmt.Sprintf("Unable to set user %d as team %d captain, got error: %s", cap, res.ID, err),
+			fmt.Sprintf("Unable to create team, got error: %s", err),
 		)
 		return
 	}
 
+	data.ID = types.StringValue(strconv.Itoa(res.ID))
+	data.Fields, diags = fieldValues(ctx, res.Fields, data.Fields)
+	resp.Diagnostics.Append(diags...)
+
+	resp.Diagnostics.Append(r.createMembers(ctx, res.ID, data)...)
 	if resp.Diagnostics.HasError() {
+		rollbackCreate(ctx, resp, "Team", data.ID.ValueString(), func(ctx context.Context) error {
+			return r.client.DeleteTeam(res.ID, api.WithContext(ctx))
+		})
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -199,8 +252,12 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	teamId := utils.Atoi(data.ID.ValueString())
-	res, err := r.client.GetTeam(teamId, api.WithContext(ctx))
-	if err != nil {
+	res := &ctfdTeam{}
+	if err := apiGet(ctx, r.client, "/teams/"+data.ID.ValueString(), res); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to read team %s, got error: %s", data.ID.ValueString(), err),
@@ -215,6 +272,13 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Country = types.StringPointerValue(res.Country)
 	data.Hidden = types.BoolValue(res.Hidden)
 	data.Banned = types.BoolValue(res.Banned)
+	data.BracketID = types.StringNull()
+	if res.BracketID != nil {
+		data.BracketID = types.StringValue(strconv.Itoa(*res.BracketID))
+	}
+	fields, diags := fieldValues(ctx, res.Fields, data.Fields)
+	resp.Diagnostics.Append(diags...)
+	data.Fields = fields
 	// password is not returned, which is good :)
 
 	// => Members
@@ -240,31 +304,52 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data teamResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if !data.BracketID.IsNull() {
+		requireVersion(&resp.Diagnostics, r.version, "Team brackets", 3, 7)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var prevFields types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("fields"), &prevFields)...)
+	fields, diags := fieldEntries(ctx, r.client, data.Fields, prevFields)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	teamId := utils.Atoi(data.ID.ValueString())
-	_, err := r.client.PatchTeam(teamId, &api.PatchTeamsParams{
-		Name:        data.Name.ValueStringPointer(),
-		Email:       data.Email.ValueStringPointer(),
-		Password:    data.Password.ValueStringPointer(),
-		Website:     data.Website.ValueStringPointer(),
-		Affiliation: data.Affiliation.ValueStringPointer(),
-		Country:     data.Country.ValueStringPointer(),
-		Hidden:      data.Hidden.ValueBoolPointer(),
-		Banned:      data.Banned.ValueBoolPointer(),
-		Fields:      []api.Field{},
-	}, api.WithContext(ctx))
-	if err != nil {
+	res := &ctfdTeam{}
+	if err := apiPatch(ctx, r.client, "/teams/"+data.ID.ValueString(), &patchTeamsParams{
+		PatchTeamsParams: api.PatchTeamsParams{
+			Name:        data.Name.ValueStringPointer(),
+			Email:       data.Email.ValueStringPointer(),
+			Password:    data.Password.ValueStringPointer(),
+			Website:     data.Website.ValueStringPointer(),
+			Affiliation: data.Affiliation.ValueStringPointer(),
+			Country:     data.Country.ValueStringPointer(),
+			Hidden:      data.Hidden.ValueBoolPointer(),
+			Banned:      data.Banned.ValueBoolPointer(),
+		},
+		BracketID: bracketID(data.BracketID),
+		Fields:    fields,
+	}, res); err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to update team, got error: %s", err),
 		)
 		return
 	}
+	data.Fields, diags = fieldValues(ctx, res.Fields, data.Fields)
+	resp.Diagnostics.Append(diags...)
 
 	// => Members
 	currentMembers, err := r.client.GetTeamMembers(teamId, api.WithContext(ctx))
@@ -322,11 +407,10 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		data.Members = members
 	}
 	// => Captain
-	cap := utils.Ptr(utils.Atoi(data.Captain.ValueString()))
-	if _, err := r.client.PatchTeam(teamId, &api.PatchTeamsParams{
+	cap := utils.Atoi(data.Captain.ValueString())
+	if err := apiPatch(ctx, r.client, "/teams/"+data.ID.ValueString(), &patchTeamCaptainParams{
 		CaptainID: cap,
-		Fields:    []api.Field{},
-	}); err != nil {
+	}, nil); err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to set user %d as team %d captain, got error: %s", cap, teamId, err),
@@ -341,6 +425,11 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data teamResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -348,6 +437,10 @@ func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	if err := r.client.DeleteTeam(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete team %s, got error: %s", data.ID.ValueString(), err),
@@ -361,3 +454,38 @@ func (r *teamResource) ImportState(ctx context.Context, req resource.ImportState
 
 	// Automatically call r.Read
 }
+
+func (r *teamResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	resp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, resp)
+	return setsUpgraders(resp.Schema)
+}
+
+// createMembers adds the members to the freshly created team, then sets
+// its captain.
+func (r *teamResource) createMembers(ctx context.Context, teamID int, data teamResourceModel) (diags diag.Diagnostics) {
+	// => Members
+	for _, mem := range data.Members {
+		_, err := r.client.PostTeamMembers(teamID, &api.PostTeamsMembersParams{
+			UserID: utils.Atoi(mem.ValueString()),
+		}, api.WithContext(ctx))
+		if err != nil {
+			diags.AddError(
+				"Client Error",
+				fmt.Sprintf("Unable to add user to team %d, got error: %s", teamID, err),
+			)
+			return
+		}
+	}
+	// => Captain
+	cap := utils.Atoi(data.Captain.ValueString())
+	if err := apiPatch(ctx, r.client, "/teams/"+strconv.Itoa(teamID), &patchTeamCaptainParams{
+		CaptainID: cap,
+	}, nil); err != nil {
+		diags.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to set user %d as team %d captain, got error: %s", cap, teamID, err),
+		)
+	}
+	return
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = (*tokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure      = (*tokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose          = (*tokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithValidateConfig = (*tokenEphemeralResource)(nil)
+)
+
+// tokenPrivateKey is the private data key holding the token identifier,
+// to revoke it on close.
+const tokenPrivateKey = "token_id"
+
+func NewTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &tokenEphemeralResource{}
+}
+
+type tokenEphemeralResource struct {
+	client   *api.Client
+	readOnly bool
+}
+
+type tokenEphemeralResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	Expiration  types.String `tfsdk:"expiration"`
+	Value       types.String `tfsdk:"value"`
+}
+
+func (r *tokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (r *tokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An API token of the user the provider is authenticated as, e.g. to hand it to a scoreboard exporter or a challenge checker. It is created when opened and deleted when closed, so it is never stored in the state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the token, used internally to handle the CTFd corresponding object.",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the token, e.g. the tool it is handed to.",
+				Optional:            true,
+			},
+			"expiration": schema.StringAttribute{
+				MarkdownDescription: "Expiration date of the token, formatted as `YYYY-MM-DD`. If not set, CTFd defaults it to 30 days.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The API token value.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *tokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data tokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Expiration.IsNull() || data.Expiration.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.DateOnly, data.Expiration.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expiration"),
+			"Invalid expiration",
+			fmt.Sprintf("The expiration date must be formatted as YYYY-MM-DD, got error: %s", err),
+		)
+	}
+}
+
+func (r *tokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.readOnly = data.ReadOnly
+}
+
+func (r *tokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data tokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Terraform opens ephemeral resources on plan too, so rather than
+	// failing (e.g. a drift report), the token is not created.
+	if r.readOnly {
+		resp.Diagnostics.AddWarning(
+			"Read-Only Provider",
+			"The provider is configured with read_only, so the token is not created and its value is null. Unset read_only (or CTFD_READ_ONLY) to create it.",
+		)
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+		return
+	}
+
+	// Create token
+	res, err := r.client.PostTokens(&api.PostTokensParams{
+		Description: data.Description.ValueString(),
+		Expiration:  data.Expiration.ValueString(),
+	}, api.WithContext(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Client Error",
+			fmt.Sprintf("Unable to create token, got error: %s", err),
+		)
+		return
+	}
+	if res.Value == nil {
+		resp.Diagnostics.AddError(
+			"CTFd Error",
+			fmt.Sprintf("CTFd returned no value for token %d.", res.ID),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created a token")
+
+	// Keep the identifier to revoke the token on close
+	id := strconv.Itoa(res.ID)
+	b, _ := json.Marshal(id)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, tokenPrivateKey, b)...)
+
+	data.ID = types.StringValue(id)
+	data.Value = types.StringValue(*res.Value)
+	if data.Expiration.IsNull() {
+		data.Expiration = types.StringValue(res.Expiration)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *tokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	b, diags := req.Private.GetKey(ctx, tokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || b == nil {
+		return
+	}
+	var id string
+	if err := json.Unmarshal(b, &id); err != nil {
+		resp.Diagnostics.AddError(
+			"Provider Error",
+			fmt.Sprintf("Unable to read token identifier from private data, got error: %s", err),
+		)
+		return
+	}
+
+	if err := r.client.DeleteToken(id, api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already revoked out of band
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete token %s, got error: %s", id, err))
+		return
+	}
+}
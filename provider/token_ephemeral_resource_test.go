@@ -0,0 +1,54 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAcc_Token_Lifecycle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"ctfd": providerserver.NewProtocol6WithError(provider.New("test")()),
+			"echo": echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+ephemeral "ctfd_token" "exporter" {
+	description = "Scoreboard exporter token."
+	expiration  = "2222-01-01"
+}
+
+provider "echo" {
+	data = ephemeral.ctfd_token.exporter
+}
+
+resource "echo" "token" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("echo.token", "data.id"),
+					resource.TestCheckResourceAttrSet("echo.token", "data.value"),
+					resource.TestCheckResourceAttr("echo.token", "data.expiration", "2222-01-01"),
+				),
+			},
+			{
+				Config: providerConfig + `
+ephemeral "ctfd_token" "exporter" {
+	expiration = "tomorrow"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid expiration`),
+			},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package transport
+
+import (
+	"net/http"
+)
+
+// Header is an http.RoundTripper that adds headers to every request, e.g.
+// the service tokens of an identity-aware proxy in front of CTFd.
+// Headers already set on the request are not overridden, so the ones of
+// the CTFd API client (e.g. Authorization) take precedence.
+type Header struct {
+	// Next is the round tripper to send requests to, http.DefaultTransport if nil.
+	Next http.RoundTripper
+
+	// Header to add to every request.
+	Header http.Header
+}
+
+var _ http.RoundTripper = (*Header)(nil)
+
+func (h *Header) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := h.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if len(h.Header) == 0 {
+		return next.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request
+	req = req.Clone(req.Context())
+	for key, values := range h.Header {
+		if _, ok := req.Header[key]; ok {
+			continue
+		}
+		req.Header[key] = append([]string(nil), values...)
+	}
+	return next.RoundTrip(req)
+}
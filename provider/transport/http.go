@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Options configures the HTTP transport to reach CTFd.
+type Options struct {
+	// CACertPEM are the PEM-encoded certificates of the authorities to trust,
+	// in addition to the system ones.
+	CACertPEM []byte
+
+	// ClientCertPEM and ClientKeyPEM are the PEM-encoded certificate and key
+	// to authenticate with on mTLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	// InsecureSkipVerify disables the verification of the CTFd certificate.
+	InsecureSkipVerify bool
+
+	// ProxyURL is the proxy to reach CTFd through. If empty, the proxy is
+	// defined by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+}
+
+// New creates an HTTP transport from the default one, configured with opts.
+func New(opts Options) (*http.Transport, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if len(opts.CACertPEM) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, errors.New("no valid certificate found in CA certificates PEM")
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+
+	if len(opts.ClientCertPEM) != 0 || len(opts.ClientKeyPEM) != 0 {
+		cert, err := tls.X509KeyPair(opts.ClientCertPEM, opts.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.ProxyURL != "" {
+		u, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q, expected e.g. http://proxy.lan:3128", opts.ProxyURL)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+
+	return tr, nil
+}
@@ -0,0 +1,203 @@
+package transport_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/transport"
+)
+
+func TestNew_CACert(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	var tests = map[string]struct {
+		Options   transport.Options
+		ExpectErr bool
+	}{
+		"unknown-authority": {
+			Options:   transport.Options{},
+			ExpectErr: true,
+		},
+		"ca-cert": {
+			Options: transport.Options{
+				CACertPEM: caPEM,
+			},
+		},
+		"insecure-skip-verify": {
+			Options: transport.Options{
+				InsecureSkipVerify: true,
+			},
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			tr, err := transport.New(tt.Options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+			if (err != nil) != tt.ExpectErr {
+				t.Fatalf("expected error: %t, got: %v", tt.ExpectErr, err)
+			}
+			if err == nil {
+				res.Body.Close()
+			}
+		})
+	}
+}
+
+func TestNew_ClientCert(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := selfSignedCert(t)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAnyClientCert,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	tr, err := transport.New(transport.Options{
+		ClientCertPEM:      certPEM,
+		ClientKeyPEM:       keyPEM,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", res.StatusCode)
+	}
+}
+
+func TestNew_Proxy(t *testing.T) {
+	t.Parallel()
+
+	proxied := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	tr, err := transport.New(transport.Options{
+		ProxyURL: proxy.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&http.Client{Transport: tr}).Get("http://ctfd.invalid/api/v1/challenges")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if proxied != "http://ctfd.invalid/api/v1/challenges" {
+		t.Errorf("expected request to go through proxy, got %q", proxied)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	t.Parallel()
+
+	var tests = map[string]transport.Options{
+		"ca-cert":     {CACertPEM: []byte("not a certificate")},
+		"client-cert": {ClientCertPEM: []byte("not a certificate")},
+		"proxy-url":   {ProxyURL: "proxy.lan"},
+	}
+
+	for testname, opts := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := transport.New(opts); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestTimeout_Retry(t *testing.T) {
+	t.Parallel()
+
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{
+		Transport: &transport.Retry{
+			Next: &transport.Timeout{
+				Timeout: 50 * time.Millisecond,
+			},
+			MaxRetries: 1,
+			MinWait:    time.Millisecond,
+		},
+	}
+	res, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("expected the timed out attempt to be retried, got: %s", err)
+	}
+	res.Body.Close()
+	if h := hits.Load(); h != 2 {
+		t.Errorf("expected 2 hits, got %d", h)
+	}
+}
+
+func selfSignedCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-ctfd"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Limit is an http.RoundTripper that caps the rate and the concurrency of
+// the requests, such that a CTFd instance with few workers is not overwhelmed
+// by Terraform parallelism.
+type Limit struct {
+	next     http.RoundTripper
+	interval time.Duration
+	sem      chan struct{}
+
+	mx   sync.Mutex
+	last time.Time
+}
+
+var _ http.RoundTripper = (*Limit)(nil)
+
+// NewLimit creates a Limit round tripper over next (http.DefaultTransport if
+// nil) that sends at most requestsPerSecond requests per second, and at most
+// maxConcurrent requests at once. A zero value disables the corresponding limit.
+func NewLimit(next http.RoundTripper, requestsPerSecond float64, maxConcurrent int) *Limit {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	l := &Limit{
+		next: next,
+	}
+	if requestsPerSecond > 0 {
+		l.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	if maxConcurrent > 0 {
+		l.sem = make(chan struct{}, maxConcurrent)
+	}
+	return l
+}
+
+func (l *Limit) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// Wait for a concurrency slot
+	release := func() {}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() {
+			once.Do(func() { <-l.sem })
+		}
+	}
+
+	// Wait for the request turn
+	if wait := l.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			release()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	res, err := l.next.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// The slot is held until the response is consumed, e.g. a file download
+	res.Body = &releaseBody{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// reserve books the next request slot, and returns the wait until it.
+func (l *Limit) reserve() time.Duration {
+	if l.interval == 0 {
+		return 0
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	now := time.Now()
+	next := l.last.Add(l.interval)
+	if next.Before(now) {
+		next = now
+	}
+	l.last = next
+	return next.Sub(now)
+}
+
+// releaseBody releases the concurrency slot of the request once closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,106 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/transport"
+)
+
+func TestLimit_MaxConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var inflight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cur := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: transport.NewLimit(nil, 0, 2)}
+
+	wg := sync.WaitGroup{}
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := client.Get(srv.URL)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", p)
+	}
+}
+
+func TestLimit_RequestsPerSecond(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: transport.NewLimit(nil, 50, 0)}
+
+	start := time.Now()
+	for range 6 {
+		res, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+	// The first request is immediate, then one every 20ms
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected at least 100ms for 6 requests at 50 rps, got %s", elapsed)
+	}
+}
+
+func TestLimit_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	client := &http.Client{Transport: transport.NewLimit(nil, 0, 1)}
+
+	// Hold the only slot
+	go func() {
+		res, err := client.Get(srv.URL)
+		if err == nil {
+			res.Body.Close()
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+}
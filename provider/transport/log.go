@@ -0,0 +1,239 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem the CTFd API requests are logged in.
+// Its level is set by the TF_LOG_PROVIDER_CTFD_HTTP environment variable,
+// e.g. to TRACE to log the bodies.
+const LogSubsystem = "http"
+
+// maxLoggedBody is the size above which a body is not logged.
+const maxLoggedBody = 64 * 1024
+
+// redacted replaces the sensitive values in the logs, as tflog masking does.
+const redacted = "***"
+
+// Log is an http.RoundTripper that logs the requests and their responses
+// in the LogSubsystem tflog subsystem: the method, path, status and latency
+// at DEBUG, the headers and bodies at TRACE.
+// The credentials, passwords, flag contents, token values and file contents
+// are redacted.
+type Log struct {
+	// Next is the round tripper to log, http.DefaultTransport if nil.
+	Next http.RoundTripper
+
+	// Sensitive values masked wherever they appear in the logs, e.g. the
+	// API key or the custom headers values.
+	Sensitive []string
+}
+
+var _ http.RoundTripper = (*Log)(nil)
+
+func (l *Log) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := l.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	ctx := tflog.NewSubsystem(req.Context(), LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_CTFD", LogSubsystem), tflog.WithRootFields())
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_path", req.URL.Path)
+	for _, v := range l.Sensitive {
+		if v != "" {
+			ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, v)
+		}
+	}
+
+	// Peek the request body, without consuming the one sent
+	reqBody := ""
+	if req.Body != nil && req.Body != http.NoBody {
+		var b []byte
+		req = req.Clone(req.Context())
+		b, req.Body = peek(req.Body)
+		reqBody = redactBody(req.URL.Path, req.Header.Get("Content-Type"), b)
+	}
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Sending CTFd API request", map[string]any{
+		"http_request_headers": redactHeader(req.Header),
+		"http_request_body":    reqBody,
+	})
+
+	start := time.Now()
+	res, err := next.RoundTrip(req)
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_duration_ms", time.Since(start).Milliseconds())
+	if err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "CTFd API request failed", map[string]any{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+	ctx = tflog.SubsystemSetField(ctx, LogSubsystem, "http_status", res.StatusCode)
+	tflog.SubsystemDebug(ctx, LogSubsystem, "CTFd API request")
+
+	b, body := peek(res.Body)
+	res.Body = body
+	tflog.SubsystemTrace(ctx, LogSubsystem, "Received CTFd API response", map[string]any{
+		"http_response_headers": redactHeader(res.Header),
+		"http_response_body":    redactBody(req.URL.Path, res.Header.Get("Content-Type"), b),
+	})
+	return res, nil
+}
+
+// peek reads up to maxLoggedBody+1 bytes of body, and returns them along
+// with a body to read instead of it, as if it was not read.
+// A read error is returned by the latter once the bytes are read.
+func peek(body io.ReadCloser) ([]byte, io.ReadCloser) {
+	b, err := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	rest := io.Reader(body)
+	if err != nil {
+		rest = &errReader{err: err}
+	}
+	return b, &peekedBody{
+		Reader: io.MultiReader(bytes.NewReader(b), rest),
+		Closer: body,
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// sensitiveHeaders are the headers carrying credentials.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Csrf-Token",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// redactHeader returns a copy of header without credentials.
+func redactHeader(header http.Header) map[string]string {
+	m := make(map[string]string, len(header))
+	for key, values := range header {
+		m[key] = strings.Join(values, ", ")
+	}
+	for _, key := range sensitiveHeaders {
+		if _, ok := m[key]; ok {
+			m[key] = redacted
+		}
+	}
+	return m
+}
+
+// redactBody returns the body of a request or response to the CTFd API
+// path, without the passwords, flag contents, token values, secret
+// configurations nor the files contents. Only JSON and form bodies are logged.
+func redactBody(path, contentType string, b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	if len(b) > maxLoggedBody {
+		return fmt.Sprintf("(%d bytes or more, not logged)", maxLoggedBody)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		var v any
+		if err := json.Unmarshal(b, &v); err != nil {
+			return fmt.Sprintf("(%d bytes of invalid JSON, not logged)", len(b))
+		}
+		out, _ := json.Marshal(redactJSON(path, v))
+		return string(out)
+
+	case "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(b))
+		if err != nil {
+			return fmt.Sprintf("(%d bytes of invalid form, not logged)", len(b))
+		}
+		for key := range values {
+			if sensitiveKey(path, key) {
+				values.Set(key, redacted)
+			}
+		}
+		return values.Encode()
+	}
+
+	// Neither files contents (multipart uploads and downloads) nor pages,
+	// which embed the session nonce, are logged
+	return fmt.Sprintf("(%d bytes of %s, not logged)", len(b), contentType)
+}
+
+// redactJSON redacts the sensitive values of a decoded JSON value.
+func redactJSON(path string, v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		// Configurations are listed as key and value entries
+		if key, ok := v["key"].(string); ok && strings.Contains(path, "/configs") && secretConfig(key) {
+			if _, ok := v["value"]; ok {
+				v["value"] = redacted
+			}
+		}
+		for key, value := range v {
+			if sensitiveKey(path, key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactJSON(path, value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactJSON(path, value)
+		}
+	}
+	return v
+}
+
+// sensitiveKey returns whether the key of a body to the CTFd API path holds
+// a secret: passwords and nonces, flag contents, token values and secret
+// configurations.
+func sensitiveKey(path, key string) bool {
+	key = strings.ToLower(key)
+	switch {
+	case strings.Contains(key, "password"), key == "nonce":
+		return true
+	case key == "content":
+		return strings.Contains(path, "/flags")
+	case key == "value":
+		// Token values, and single configurations such as mail_password
+		return strings.Contains(path, "/tokens") || (strings.Contains(path, "/configs/") && secretConfig(path))
+	}
+	// Configurations are patched as a key to value map
+	return strings.Contains(path, "/configs") && secretConfig(key)
+}
+
+// secretConfigs are the parts of the CTFd configurations keys that hold
+// secrets, e.g. mail_password, mail_username or oauth_client_secret.
+var secretConfigs = []string{"password", "secret", "token", "username", "registration_code"}
+
+// secretConfig returns whether the CTFd configuration key holds a secret.
+func secretConfig(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretConfigs {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package transport
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// NotFoundError is returned, wrapped by the *http.Client, when CTFd
+// responds 404 Not Found, e.g. when reading an object deleted out of band.
+type NotFoundError struct {
+	Method string
+	Path   string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("CTFd responded with 404 Not Found to %s %s", e.Method, e.Path)
+}
+
+// NotFound is an http.RoundTripper that turns the 404 Not Found responses
+// into *NotFoundError, as the CTFd API client does not expose the status.
+// It must be above Retry, such that the error is not retried.
+type NotFound struct {
+	// Next is the round tripper to send requests to, http.DefaultTransport if nil.
+	Next http.RoundTripper
+}
+
+var _ http.RoundTripper = (*NotFound)(nil)
+
+func (n *NotFound) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := n.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	res, err := next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusNotFound {
+		return res, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+	_ = res.Body.Close()
+	return nil, &NotFoundError{
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+}
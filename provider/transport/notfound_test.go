@@ -0,0 +1,48 @@
+package transport_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/transport"
+)
+
+func TestNotFound_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hits := atomic.Int64{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path != "/api/v1/challenges/1" {
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{
+		Transport: &transport.NotFound{
+			Next: newRetry(),
+		},
+	}
+
+	res, err := client.Get(srv.URL + "/api/v1/challenges/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = res.Body.Close()
+
+	_, err = client.Get(srv.URL + "/api/v1/challenges/2")
+	var nf *transport.NotFoundError
+	if !errors.As(err, &nf) {
+		t.Fatalf("expected a *transport.NotFoundError, got: %v", err)
+	}
+	if nf.Method != http.MethodGet || nf.Path != "/api/v1/challenges/2" {
+		t.Errorf("unexpected error: %v", nf)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected not found not to be retried, got %d requests", got)
+	}
+}
@@ -0,0 +1,185 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Retry is an http.RoundTripper that retries the requests that failed due to
+// transient errors (e.g. a 503 from a reverse proxy, or a connection reset),
+// with an exponential backoff.
+//
+// Idempotent requests (e.g. GET, PATCH or DELETE) are retried on any of those
+// errors, but not on permanent ones (e.g. an untrusted certificate). Other ones (e.g. POST) are only retried when CTFd could not have
+// processed them, i.e. when the connection could not be established or when
+// it explicitly refused them (429 and 503).
+type Retry struct {
+	// Next is the round tripper to retry, http.DefaultTransport if nil.
+	Next http.RoundTripper
+
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+
+	// MinWait is the wait before the first retry, doubled on every retry.
+	MinWait time.Duration
+
+	// MaxWait is the maximum wait between two attempts.
+	MaxWait time.Duration
+}
+
+var _ http.RoundTripper = (*Retry)(nil)
+
+func (r *Retry) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := r.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if r.MaxRetries <= 0 {
+		return next.RoundTrip(req)
+	}
+
+	// Make sure the body could be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(b))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(b)), nil
+		}
+	}
+
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		outreq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			outreq = req.Clone(ctx)
+			outreq.Body = body
+		}
+
+		res, err := next.RoundTrip(outreq)
+		if attempt >= r.MaxRetries || !retryable(req, res, err) {
+			return res, err
+		}
+
+		wait := r.backoff(attempt, res)
+		fields := map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status_code"] = res.StatusCode
+			// Drain the body so the connection could be reused
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		tflog.Debug(ctx, "Retrying CTFd API request", fields)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable returns whether the request could be sent again given the
+// result of the last attempt.
+func retryable(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		// The request was canceled, while a Timeout of the attempt is transient
+		if req.Context().Err() != nil {
+			return false
+		}
+		if idempotent(req) {
+			return transient(err)
+		}
+		// The request was not sent if the connection could not be established
+		return dialError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(req)
+	}
+	return false
+}
+
+// transient returns whether err is a network error that may not happen on
+// the next attempt (e.g. a timeout or a connection reset), unlike the ones
+// due to a misconfiguration (e.g. an untrusted certificate or proxy).
+func transient(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		dialError(err)
+}
+
+// dialError returns whether err is due to the connection failing to be
+// established, thus the request was not sent.
+func dialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// idempotent returns whether the request could be sent multiple times with
+// the same effect, as net/http considers it.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	_, hasKey := req.Header["Idempotency-Key"]
+	_, hasXKey := req.Header["X-Idempotency-Key"]
+	return hasKey || hasXKey
+}
+
+// backoff returns the wait before the next attempt, honoring the
+// Retry-After header if any.
+func (r *Retry) backoff(attempt int, res *http.Response) time.Duration {
+	wait := r.MinWait
+	for i := 0; i < attempt && wait < r.MaxWait; i++ {
+		wait *= 2
+	}
+	if res != nil {
+		if s, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && s > 0 {
+			wait = time.Duration(s) * time.Second
+		}
+	}
+	if r.MaxWait > 0 && wait > r.MaxWait {
+		wait = r.MaxWait
+	}
+	return wait
+}
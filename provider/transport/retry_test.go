@@ -0,0 +1,274 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ctfer-io/go-ctfd/api"
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/transport"
+)
+
+// faultyServer is a CTFd stand-in that fails the first requests with the
+// given faults, then answers successfully.
+type faultyServer struct {
+	*httptest.Server
+
+	faults []fault
+	hits   atomic.Int32
+	bodies []string
+}
+
+// fault is either an HTTP status code to respond, or 0 to reset the
+// connection.
+type fault int
+
+const reset fault = 0
+
+func newFaultyServer(t *testing.T, faults ...fault) *faultyServer {
+	fs := &faultyServer{
+		faults: faults,
+	}
+	fs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		fs.bodies = append(fs.bodies, string(b))
+
+		hit := int(fs.hits.Add(1)) - 1
+		if hit < len(fs.faults) {
+			if fs.faults[hit] == reset {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Errorf("hijacking connection: %s", err)
+					return
+				}
+				if tcp, ok := conn.(*net.TCPConn); ok {
+					_ = tcp.SetLinger(0)
+				}
+				conn.Close()
+				return
+			}
+			w.WriteHeader(int(fs.faults[hit]))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "data": []}`))
+	}))
+	t.Cleanup(fs.Close)
+	return fs
+}
+
+func newRetry() *transport.Retry {
+	return &transport.Retry{
+		MaxRetries: 3,
+		MinWait:    time.Millisecond,
+		MaxWait:    5 * time.Millisecond,
+	}
+}
+
+func TestRetry_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var tests = map[string]struct {
+		Method       string
+		Faults       []fault
+		TLS          bool
+		ExpectedHits int
+		ExpectStatus int
+		ExpectErr    bool
+	}{
+		"get-success": {
+			Method:       http.MethodGet,
+			ExpectedHits: 1,
+			ExpectStatus: http.StatusOK,
+		},
+		"get-transient": {
+			Method:       http.MethodGet,
+			Faults:       []fault{http.StatusServiceUnavailable, http.StatusBadGateway, reset},
+			ExpectedHits: 4,
+			ExpectStatus: http.StatusOK,
+		},
+		"get-exhausted": {
+			Method:       http.MethodGet,
+			Faults:       []fault{502, 502, 502, 502, 502},
+			ExpectedHits: 4,
+			ExpectStatus: http.StatusBadGateway,
+		},
+		"get-not-transient": {
+			Method:       http.MethodGet,
+			Faults:       []fault{http.StatusInternalServerError},
+			ExpectedHits: 1,
+			ExpectStatus: http.StatusInternalServerError,
+		},
+		"patch-transient": {
+			Method:       http.MethodPatch,
+			Faults:       []fault{http.StatusGatewayTimeout, reset},
+			ExpectedHits: 3,
+			ExpectStatus: http.StatusOK,
+		},
+		"delete-transient": {
+			Method:       http.MethodDelete,
+			Faults:       []fault{http.StatusBadGateway},
+			ExpectedHits: 2,
+			ExpectStatus: http.StatusOK,
+		},
+		"post-refused": {
+			Method:       http.MethodPost,
+			Faults:       []fault{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			ExpectedHits: 3,
+			ExpectStatus: http.StatusOK,
+		},
+		"post-bad-gateway": {
+			Method:       http.MethodPost,
+			Faults:       []fault{http.StatusBadGateway},
+			ExpectedHits: 1,
+			ExpectStatus: http.StatusBadGateway,
+		},
+		"post-reset": {
+			Method:       http.MethodPost,
+			Faults:       []fault{reset},
+			ExpectedHits: 1,
+			ExpectErr:    true,
+		},
+		"get-untrusted-certificate": {
+			Method:       http.MethodGet,
+			TLS:          true,
+			ExpectedHits: 1,
+			ExpectErr:    true,
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			srv := newFaultyServer(t, tt.Faults...)
+			url := srv.URL
+			if tt.TLS {
+				// Its certificate is not trusted by the client
+				tlsSrv := httptest.NewUnstartedServer(srv.Config.Handler)
+				tlsSrv.Config.ErrorLog = log.New(io.Discard, "", 0)
+				tlsSrv.StartTLS()
+				t.Cleanup(tlsSrv.Close)
+				url = tlsSrv.URL
+			}
+			attempts := 0
+			retry := newRetry()
+			retry.Next = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				return http.DefaultTransport.RoundTrip(req)
+			})
+			client := &http.Client{Transport: retry}
+
+			req, _ := http.NewRequest(tt.Method, url, strings.NewReader(`{"name":"chall"}`))
+			res, err := client.Do(req)
+			if (err != nil) != tt.ExpectErr {
+				t.Fatalf("expected error: %t, got: %v", tt.ExpectErr, err)
+			}
+			if err == nil {
+				defer res.Body.Close()
+				if res.StatusCode != tt.ExpectStatus {
+					t.Errorf("expected status %d, got %d", tt.ExpectStatus, res.StatusCode)
+				}
+			}
+			// The TLS handshake fails before reaching the server
+			hits := int(srv.hits.Load())
+			if tt.TLS {
+				hits = attempts
+			}
+			if hits != tt.ExpectedHits {
+				t.Errorf("expected %d hits, got %d", tt.ExpectedHits, hits)
+			}
+			for i, body := range srv.bodies {
+				if body != `{"name":"chall"}` {
+					t.Errorf("attempt %d: unexpected body %q", i, body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetry_PostDial(t *testing.T) {
+	t.Parallel()
+
+	// Get an address nothing listens on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	hits := 0
+	client := &http.Client{
+		Transport: &transport.Retry{
+			Next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				hits++
+				return http.DefaultTransport.RoundTrip(req)
+			}),
+			MaxRetries: 2,
+			MinWait:    time.Millisecond,
+		},
+	}
+	req, _ := http.NewRequest(http.MethodPost, "http://"+addr, strings.NewReader("{}"))
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected error")
+	}
+	if hits != 3 {
+		t.Errorf("expected 3 attempts, got %d", hits)
+	}
+}
+
+func TestRetry_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	srv := newFaultyServer(t, 503, 503, 503)
+	client := &http.Client{
+		Transport: &transport.Retry{
+			MaxRetries: 3,
+			MinWait:    time.Hour,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if _, err := client.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+	if hits := srv.hits.Load(); hits != 1 {
+		t.Errorf("expected 1 hit, got %d", hits)
+	}
+}
+
+func TestRetry_CTFdClient(t *testing.T) {
+	t.Parallel()
+
+	srv := newFaultyServer(t, 503, reset)
+	client := api.NewClient(srv.URL, "", "", "ctfd_key")
+	sub, err := transport.HTTPClient(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub.Transport = newRetry()
+
+	if _, err := client.GetChallenges(nil); err != nil {
+		t.Fatalf("expected success, got: %s", err)
+	}
+	if hits := srv.hits.Load(); hits != 3 {
+		t.Errorf("expected 3 hits, got %d", hits)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,51 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Timeout is an http.RoundTripper that bounds the duration of a request,
+// including the read of its response body. Used under Retry, it bounds
+// every attempt rather than the whole request.
+type Timeout struct {
+	// Next is the round tripper to bound, http.DefaultTransport if nil.
+	Next http.RoundTripper
+
+	// Timeout of a request, no timeout if zero.
+	Timeout time.Duration
+}
+
+var _ http.RoundTripper = (*Timeout)(nil)
+
+func (t *Timeout) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if t.Timeout <= 0 {
+		return next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+	res, err := next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelBody cancels the context of the request once closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
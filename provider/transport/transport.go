@@ -0,0 +1,25 @@
+// Package transport provides the HTTP round trippers the provider wraps the
+// CTFd API client with, e.g. to retry on transient errors.
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/ctfer-io/go-ctfd/api"
+)
+
+// HTTPClient returns the *http.Client used by the CTFd API client, such that
+// its transport and timeout could be configured.
+// go-ctfd does not expose it, so it is reached through reflection.
+func HTTPClient(client *api.Client) (*http.Client, error) {
+	if client == nil {
+		return nil, errors.New("nil CTFd API client")
+	}
+	sub := reflect.ValueOf(client).Elem().FieldByName("sub")
+	if !sub.IsValid() || sub.Type() != reflect.TypeOf((*http.Client)(nil)) || sub.IsNil() {
+		return nil, errors.New("CTFd API client does not hold an *http.Client, go-ctfd may have changed")
+	}
+	return (*http.Client)(sub.UnsafePointer()), nil
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// setsUpgraders returns the state upgraders of a resource of schema s from
+// the version 0, when its set attributes were lists.
+func setsUpgraders(s schema.Schema) map[int64]resource.StateUpgrader {
+	prior := schema.Schema{
+		Attributes: setsToLists(s.Attributes),
+	}
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &prior,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				raw, err := listsToSets(req.State.Raw, resp.State.Schema.Type().TerraformType(ctx))
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Unable to convert lists to sets, got error: %s", err),
+					)
+					return
+				}
+				resp.State.Raw = raw
+			},
+		},
+	}
+}
+
+// setsToLists returns a copy of attrs with the set attributes turned to lists.
+func setsToLists(attrs map[string]schema.Attribute) map[string]schema.Attribute {
+	lists := make(map[string]schema.Attribute, len(attrs))
+	for name, attr := range attrs {
+		switch attr := attr.(type) {
+		case schema.SetAttribute:
+			lists[name] = schema.ListAttribute{
+				MarkdownDescription: attr.MarkdownDescription,
+				ElementType:         attr.ElementType,
+				Required:            attr.Required,
+				Optional:            attr.Optional,
+				Computed:            attr.Computed,
+				Sensitive:           attr.Sensitive,
+			}
+		case schema.SingleNestedAttribute:
+			attr.Attributes = setsToLists(attr.Attributes)
+			lists[name] = attr
+		default:
+			lists[name] = attr
+		}
+	}
+	return lists
+}
+
+// listsToSets converts v to the type typ, turning the lists to sets
+// where typ expects so. Duplicated elements are dropped.
+func listsToSets(v tftypes.Value, typ tftypes.Type) (tftypes.Value, error) {
+	if v.IsNull() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	if !v.IsKnown() {
+		return tftypes.NewValue(typ, tftypes.UnknownValue), nil
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.Set:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return tftypes.Value{}, err
+		}
+		uniq := make([]tftypes.Value, 0, len(elems))
+		for _, elem := range elems {
+			elem, err := listsToSets(elem, typ.ElementType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			if !slices.ContainsFunc(uniq, elem.Equal) {
+				uniq = append(uniq, elem)
+			}
+		}
+		return tftypes.NewValue(typ, uniq), nil
+
+	case tftypes.List:
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return tftypes.Value{}, err
+		}
+		for i, elem := range elems {
+			elem, err := listsToSets(elem, typ.ElementType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elems[i] = elem
+		}
+		return tftypes.NewValue(typ, elems), nil
+
+	case tftypes.Object:
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return tftypes.Value{}, err
+		}
+		for name, attrType := range typ.AttributeTypes {
+			attr, ok := attrs[name]
+			if !ok {
+				attrs[name] = tftypes.NewValue(attrType, nil)
+				continue
+			}
+			attr, err := listsToSets(attr, attrType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			attrs[name] = attr
+		}
+		return tftypes.NewValue(typ, attrs), nil
+	}
+	return v, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -40,6 +41,8 @@ type userResourceModel struct {
 	Verified    types.Bool   `tfsdk:"verified"`
 	Hidden      types.Bool   `tfsdk:"hidden"`
 	Banned      types.Bool   `tfsdk:"banned"`
+	BracketID   types.String `tfsdk:"bracket_id"`
+	Fields      types.Map    `tfsdk:"fields"`
 }
 
 func NewUserResource() resource.Resource {
@@ -47,7 +50,9 @@ func NewUserResource() resource.Resource {
 }
 
 type userResource struct {
-	client *api.Client
+	client   *api.Client
+	version  *ctfdVersion
+	readOnly bool
 }
 
 func (r *userResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -125,6 +130,19 @@ func (r *userResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:            true,
 				Default:             defaults.Bool(booldefault.StaticBool(false)),
 			},
+			"bracket_id": schema.StringAttribute{
+				MarkdownDescription: "Bracket the user is part of, must be of type users. Requires CTFd 3.7 or later.",
+				Optional:            true,
+			},
+			"fields": schema.MapAttribute{
+				MarkdownDescription: "Values of the custom fields (`ctfd_field` of scope user), indexed by the field ID. Boolean values are either `true` or `false`. If not set, the values are left untouched.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -135,40 +153,85 @@ func (r *userResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*api.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *github.com/ctfer-io/go-ctfd/api.Client, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please open an issue at https://github.com/ctfer-io/terraform-provider-ctfd", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.version = data.Version
+	r.readOnly = data.ReadOnly
+}
+
+// The following types complete github.com/ctfer-io/go-ctfd/api with the
+// bracket and the fields values of the user.
+
+type ctfdUser struct {
+	api.User
+
+	BracketID *int         `json:"bracket_id"`
+	Fields    []fieldEntry `json:"fields"`
+}
+
+type postUsersParams struct {
+	api.PostUsersParams
+
+	BracketID *int         `json:"bracket_id,omitempty"`
+	Fields    []fieldEntry `json:"fields,omitempty"`
+}
+
+type patchUsersParams struct {
+	api.PatchUsersParams
+
+	// BracketID is always sent, such that null removes the user from its bracket.
+	BracketID *int `json:"bracket_id"`
+	// Fields are not sent if not managed, else CTFd would erase them.
+	Fields []fieldEntry `json:"fields,omitempty"`
 }
 
 func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data userResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if !data.BracketID.IsNull() {
+		requireVersion(&resp.Diagnostics, r.version, "User brackets", 3, 7)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	res, err := r.client.PostUsers(&api.PostUsersParams{
-		Name:        data.Name.ValueString(),
-		Email:       data.Email.ValueString(),
-		Password:    data.Password.ValueString(),
-		Website:     data.Website.ValueStringPointer(),
-		Language:    data.Language.ValueStringPointer(),
-		Affiliation: data.Affiliation.ValueStringPointer(),
-		Country:     data.Country.ValueStringPointer(),
-		Type:        data.Type.ValueString(),
-		Verified:    data.Verified.ValueBool(),
-		Hidden:      data.Hidden.ValueBool(),
-		Banned:      data.Banned.ValueBool(),
-		Fields:      []api.Field{},
-	}, api.WithContext(ctx))
-	if err != nil {
+	fields, diags := fieldEntries(ctx, r.client, data.Fields, types.MapNull(types.StringType))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res := &ctfdUser{}
+	if err := apiPost(ctx, r.client, "/users", &postUsersParams{
+		PostUsersParams: api.PostUsersParams{
+			Name:        data.Name.ValueString(),
+			Email:       data.Email.ValueString(),
+			Password:    data.Password.ValueString(),
+			Website:     data.Website.ValueStringPointer(),
+			Language:    data.Language.ValueStringPointer(),
+			Affiliation: data.Affiliation.ValueStringPointer(),
+			Country:     data.Country.ValueStringPointer(),
+			Type:        data.Type.ValueString(),
+			Verified:    data.Verified.ValueBool(),
+			Hidden:      data.Hidden.ValueBool(),
+			Banned:      data.Banned.ValueBool(),
+		},
+		BracketID: bracketID(data.BracketID),
+		Fields:    fields,
+	}, res); err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to create user, got error: %s", err),
@@ -177,6 +240,8 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	data.ID = types.StringValue(strconv.Itoa(res.ID))
+	data.Fields, diags = fieldValues(ctx, res.Fields, data.Fields)
+	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -191,8 +256,12 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	res, err := r.client.GetUser(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx))
-	if err != nil {
+	res := &ctfdUser{}
+	if err := apiGet(ctx, r.client, "/users/"+data.ID.ValueString(), res); err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to read user %s, got error: %s", data.ID.ValueString(), err),
@@ -210,6 +279,13 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Verified = types.BoolPointerValue(res.Verified)
 	data.Hidden = types.BoolPointerValue(res.Hidden)
 	data.Banned = types.BoolPointerValue(res.Banned)
+	data.BracketID = types.StringNull()
+	if res.BracketID != nil {
+		data.BracketID = types.StringValue(strconv.Itoa(*res.BracketID))
+	}
+	fields, diags := fieldValues(ctx, res.Fields, data.Fields)
+	resp.Diagnostics.Append(diags...)
+	data.Fields = fields
 	// password is not returned, which is good :)
 
 	if resp.Diagnostics.HasError() {
@@ -219,27 +295,46 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data userResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if !data.BracketID.IsNull() {
+		requireVersion(&resp.Diagnostics, r.version, "User brackets", 3, 7)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.PatchUser(utils.Atoi(data.ID.ValueString()), &api.PatchUsersParams{
-		Name:        data.Name.ValueString(),
-		Email:       data.Email.ValueString(),
-		Password:    data.Password.ValueStringPointer(),
-		Website:     data.Website.ValueStringPointer(),
-		Affiliation: data.Affiliation.ValueStringPointer(),
-		Language:    data.Language.ValueStringPointer(),
-		Country:     data.Country.ValueStringPointer(),
-		Type:        data.Type.ValueStringPointer(),
-		Verified:    data.Verified.ValueBoolPointer(),
-		Hidden:      data.Hidden.ValueBoolPointer(),
-		Banned:      data.Banned.ValueBoolPointer(),
-		Fields:      []api.Field{},
-	}, api.WithContext(ctx))
-	if err != nil {
+	var prevFields types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("fields"), &prevFields)...)
+	fields, diags := fieldEntries(ctx, r.client, data.Fields, prevFields)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res := &ctfdUser{}
+	if err := apiPatch(ctx, r.client, "/users/"+data.ID.ValueString(), &patchUsersParams{
+		PatchUsersParams: api.PatchUsersParams{
+			Name:        data.Name.ValueString(),
+			Email:       data.Email.ValueString(),
+			Password:    data.Password.ValueStringPointer(),
+			Website:     data.Website.ValueStringPointer(),
+			Affiliation: data.Affiliation.ValueStringPointer(),
+			Language:    data.Language.ValueStringPointer(),
+			Country:     data.Country.ValueStringPointer(),
+			Type:        data.Type.ValueStringPointer(),
+			Verified:    data.Verified.ValueBoolPointer(),
+			Hidden:      data.Hidden.ValueBoolPointer(),
+			Banned:      data.Banned.ValueBoolPointer(),
+		},
+		BracketID: bracketID(data.BracketID),
+		Fields:    fields,
+	}, res); err != nil {
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to update user, got error: %s", err),
@@ -247,6 +342,9 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	data.Fields, diags = fieldValues(ctx, res.Fields, data.Fields)
+	resp.Diagnostics.Append(diags...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -254,6 +352,11 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if r.readOnly {
+		resp.Diagnostics.Append(readOnlyDiagnostic())
+		return
+	}
+
 	var data userResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -261,6 +364,10 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	if err := r.client.DeleteUser(utils.Atoi(data.ID.ValueString()), api.WithContext(ctx)); err != nil {
+		if isNotFound(err) {
+			// Already deleted out of band
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Client Error",
 			fmt.Sprintf("Unable to delete user %s, got error: %s", data.ID.ValueString(), err),
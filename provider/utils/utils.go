@@ -70,3 +70,27 @@ func BlindMerge[T comparable, U any](a, b map[T]U) map[T]U {
 	}
 	return c
 }
+
+// Diff returns the values to add to current, and the indexes of the
+// ones to remove from it, such that it holds the same values as wanted,
+// as many times each. Values to add are in the order of wanted.
+func Diff[T comparable](current, wanted []T) (add []T, remove []int) {
+	missing := map[T]int{}
+	for _, v := range wanted {
+		missing[v]++
+	}
+	for i, v := range current {
+		if missing[v] > 0 {
+			missing[v]--
+			continue
+		}
+		remove = append(remove, i)
+	}
+	for _, v := range wanted {
+		if missing[v] > 0 {
+			missing[v]--
+			add = append(add, v)
+		}
+	}
+	return
+}
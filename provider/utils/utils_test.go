@@ -0,0 +1,63 @@
+package utils_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ctfer-io/terraform-provider-ctfd/v2/provider/utils"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	var tests = map[string]struct {
+		Current      []string
+		Wanted       []string
+		ExpectAdd    []string
+		ExpectRemove []int
+	}{
+		"empty": {},
+		"unchanged": {
+			Current: []string{"web", "easy"},
+			Wanted:  []string{"web", "easy"},
+		},
+		"reordered": {
+			Current: []string{"web", "easy"},
+			Wanted:  []string{"easy", "web"},
+		},
+		"create": {
+			Wanted:    []string{"web", "easy"},
+			ExpectAdd: []string{"web", "easy"},
+		},
+		"delete": {
+			Current:      []string{"web", "easy"},
+			ExpectRemove: []int{0, 1},
+		},
+		"add-and-remove": {
+			Current:      []string{"web", "easy", "sqli"},
+			Wanted:       []string{"web", "xss", "hard"},
+			ExpectAdd:    []string{"xss", "hard"},
+			ExpectRemove: []int{1, 2},
+		},
+		"duplicates": {
+			Current:      []string{"web", "web", "web", "easy"},
+			Wanted:       []string{"easy", "web", "easy"},
+			ExpectAdd:    []string{"easy"},
+			ExpectRemove: []int{1, 2},
+		},
+	}
+
+	for testname, tt := range tests {
+		t.Run(testname, func(t *testing.T) {
+			t.Parallel()
+
+			add, remove := utils.Diff(tt.Current, tt.Wanted)
+			if !slices.Equal(add, tt.ExpectAdd) {
+				t.Errorf("expected to add %v, got %v", tt.ExpectAdd, add)
+			}
+			if !slices.Equal(remove, tt.ExpectRemove) {
+				t.Errorf("expected to remove %v, got %v", tt.ExpectRemove, remove)
+			}
+		})
+	}
+}